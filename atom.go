@@ -23,7 +23,16 @@ func NewAtom(name string) Atom {
 	}
 }
 
+// NewAtomFrom creates a new [Atom] with the given name and an id from alloc.
+func NewAtomFrom(alloc util.Allocator, name string) Atom {
+	return Atom{
+		id:   alloc.NewId(),
+		name: name,
+	}
+}
+
 // OverrideID overrides the current ID of the atom.
+// Ids shared by atoms in the same tree are reported by [CheckIds].
 func (atom *Atom) OverrideID(id uint32) *Atom {
 	if id == 0 {
 		panic("ID 0 is reserved and cannot be used")
@@ -62,3 +62,18 @@ func NewAtomicProxy(name string) Atomic {
 func (AtomicProxy) Init() tea.Cmd                           { return nil }
 func (AtomicProxy) Update(msg tea.Msg) (tea.Model, tea.Cmd) { return nil, nil }
 func (ap AtomicProxy) View() string                         { return ap.Name() + " (Proxy)\n" }
+
+// AsAtomic returns next as an [Atomic], keeping the name and id of prev
+// when next does not provide its own. Containers use it to store the
+// result of updating an [Atomic] child.
+func AsAtomic(prev Atomic, next tea.Model) Atomic {
+	if atomic, ok := next.(Atomic); ok {
+		return atomic
+	}
+
+	return &teaAtomic{
+		Model:      next,
+		Named:      Named{name: prev.Name()},
+		Identified: Identified{id: prev.Id()},
+	}
+}
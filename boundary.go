@@ -0,0 +1,193 @@
+package polymer
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/trippwill/polymer/util"
+)
+
+// PanicError is reported when a model under an error boundary panics.
+// See [WithErrorBoundary].
+type PanicError struct {
+	Op    string // The method that panicked: Init, Update or View.
+	Value any    // The value passed to panic.
+	Stack []byte // The stack trace of the panic.
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic in %s: %v", e.Op, e.Value)
+}
+
+// Unwrap returns the value passed to panic if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Fallback renders the view of a failed subtree. See [WithErrorBoundary].
+type Fallback func(err *PanicError) string
+
+var fallbackStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("9")).
+	Padding(0, 1)
+
+// DefaultFallback renders the panic value and the keys to retry or dismiss.
+func DefaultFallback(err *PanicError) string {
+	return fallbackStyle.Render(fmt.Sprintf("Something went wrong\n\n%v\n\n%s retry • %s dismiss",
+		err.Value, helpKey(retryKey), helpKey(dismissKey)))
+}
+
+// WithErrorBoundary makes the Lens recover panics in the Init, Update and
+// View methods of the models beneath it.
+//
+// A panic is reported as a [*PanicError] error message, so it reaches the
+// OnError hooks, and the subtree renders fallback, or [DefaultFallback] if
+// fallback is nil, until the user retries or dismisses it, with r and esc
+// by default.
+// Retrying resumes the model as it was before the panicking Update;
+// dismissing ends the Lens as if it returned nil. Other messages are
+// dropped while the subtree has failed.
+func WithErrorBoundary(fallback Fallback) LensOption {
+	if fallback == nil {
+		fallback = DefaultFallback
+	}
+
+	return func(l *Lens) {
+		l.boundary = &boundary{fallback: fallback}
+	}
+}
+
+// boundary holds the failure of a Lens with an error boundary.
+//
+// It is shared by every copy of the Lens so a panic in View, which cannot
+// return an updated model, is still recorded.
+type boundary struct {
+	fallback Fallback
+	failure  *PanicError
+	reported bool
+	size     *tea.WindowSizeMsg
+}
+
+// failed reports whether the subtree has failed.
+func (b *boundary) failed() bool { return b != nil && b.failure != nil }
+
+// fail records a panic and returns a command reporting it.
+func (b *boundary) fail(op string, value any) tea.Cmd {
+	b.record(op, value)
+	return b.report()
+}
+
+// record records a panic to be reported by the next Update.
+func (b *boundary) record(op string, value any) {
+	b.failure = &PanicError{Op: op, Value: value, Stack: debug.Stack()}
+	b.reported = false
+}
+
+// report returns a command reporting the failure once.
+func (b *boundary) report() tea.Cmd {
+	if b.reported {
+		return nil
+	}
+
+	b.reported = true
+	return util.Broadcast(b.failure)
+}
+
+// updateFailed handles msg while the subtree of the Lens has failed.
+func (l Lens) updateFailed(msg tea.Msg) (tea.Model, tea.Cmd) {
+	report := l.boundary.report()
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return l, report
+	}
+
+	switch {
+	case retryKey.Matches(key):
+		op := l.boundary.failure.Op
+		l.boundary.failure = nil
+		if op == "Init" {
+			return l, tea.Batch(report, l.Init(), l.resize())
+		}
+		return l, tea.Batch(report, l.resize())
+
+	case dismissKey.Matches(key):
+		return nil, report
+	}
+
+	return l, report
+}
+
+// resize sends the last known window size to a retried subtree.
+func (l Lens) resize() tea.Cmd {
+	size := l.boundary.size
+	if size == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		return *size
+	}
+}
+
+// init initializes the model, recovering panics if the Lens has an error boundary.
+func (l Lens) init() (cmd tea.Cmd) {
+	if l.boundary == nil {
+		return l.Model.Init()
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			cmd = l.boundary.fail("Init", r)
+		}
+	}()
+
+	return l.Model.Init()
+}
+
+// update updates the model, recovering panics if the Lens has an error boundary.
+// After a panic the model is returned as it was before the update.
+func (l Lens) update(msg tea.Msg) (next tea.Model, cmd tea.Cmd) {
+	if l.boundary == nil {
+		return l.Model.Update(msg)
+	}
+
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		l.boundary.size = &size
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			next, cmd = l.Model, l.boundary.fail("Update", r)
+		}
+	}()
+
+	return l.Model.Update(msg)
+}
+
+// view renders the model, or the fallback if the subtree has failed.
+func (l Lens) view() (rendered string) {
+	if l.Model == nil {
+		return ""
+	}
+
+	if l.boundary == nil {
+		return l.Model.View()
+	}
+
+	if l.boundary.failed() {
+		return l.boundary.fallback(l.boundary.failure)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			l.boundary.record("View", r)
+			rendered = l.boundary.fallback(l.boundary.failure)
+		}
+	}()
+
+	return l.Model.View()
+}
@@ -0,0 +1,105 @@
+package polymer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// crasher is a probe that panics in Update on a panicMsg, and in View
+// while broken is set.
+type crasher struct {
+	probe
+	broken bool
+}
+
+type panicMsg struct{}
+
+func (c crasher) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(panicMsg); ok {
+		panic("boom")
+	}
+
+	next, cmd := c.probe.Update(msg)
+	c.probe = next.(probe)
+	return c, cmd
+}
+
+func (c crasher) View() string {
+	if c.broken {
+		panic("broken view")
+	}
+	return c.probe.View()
+}
+
+// panicErrors returns the panic errors reported by cmd.
+func panicErrors(cmd tea.Cmd) []*PanicError {
+	var errs []*PanicError
+	for _, msg := range collect(cmd) {
+		var err *PanicError
+		if e, ok := msg.(error); ok && errors.As(e, &err) {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func TestBoundaryRecoversUpdate(t *testing.T) {
+	model := crasher{probe: newProbe("model")}
+	lens := *NewLens(model, WithErrorBoundary(func(err *PanicError) string {
+		return "failed: " + err.Op
+	}))
+
+	next, cmd := lens.Update(panicMsg{})
+	if errs := panicErrors(cmd); len(errs) != 1 || errs[0].Op != "Update" || errs[0].Value != "boom" {
+		t.Fatalf("reported %v, want one panic in Update", errs)
+	}
+	if got := next.View(); got != "failed: Update" {
+		t.Errorf("View() = %q, want the fallback", got)
+	}
+
+	next, _ = next.Update("dropped")
+	if model.got("dropped") {
+		t.Errorf("failed subtree received a message")
+	}
+
+	next, _ = next.Update(runes("r"))
+	next, _ = next.Update("resumed")
+	if !model.got("resumed") || next.View() != "model" {
+		t.Errorf("retry did not resume the model: received %v, view %q", *model.received, next.View())
+	}
+}
+
+func TestBoundaryDismiss(t *testing.T) {
+	lens := *NewLens(crasher{probe: newProbe("model")}, WithErrorBoundary(nil))
+
+	next, _ := lens.Update(panicMsg{})
+	if !strings.Contains(next.View(), "boom") {
+		t.Errorf("default fallback %q does not show the panic", next.View())
+	}
+
+	next, _ = next.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if next != nil {
+		t.Errorf("dismiss returned %T, want nil", next)
+	}
+}
+
+func TestBoundaryRecoversView(t *testing.T) {
+	lens := *NewLens(crasher{probe: newProbe("model"), broken: true}, WithErrorBoundary(func(*PanicError) string {
+		return "fallback"
+	}))
+
+	if got := lens.View(); got != "fallback" {
+		t.Fatalf("View() = %q, want the fallback", got)
+	}
+
+	_, cmd := lens.Update("tick")
+	if errs := panicErrors(cmd); len(errs) != 1 || errs[0].Op != "View" {
+		t.Errorf("reported %v, want one panic in View", errs)
+	}
+	if _, cmd := lens.Update("tick"); len(panicErrors(cmd)) != 0 {
+		t.Errorf("the panic was reported twice")
+	}
+}
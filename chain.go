@@ -0,0 +1,278 @@
+package polymer
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Chain is a navigation stack of [Atomic] models.
+//
+// Only the top of the stack is updated and rendered, except for [Routed]
+// messages which reach every atom on the stack. When the top returns nil
+// from Update it is popped, and when the stack becomes empty the Chain itself
+// returns nil. Navigation messages are handled by the outermost Chain that
+// receives them; the [Host] addresses them to the outermost Chain on its
+// [ActivePath], so sibling Chains do not all act on them. To navigate a
+// particular Chain, send the message to it with [SendTo].
+//
+// When the top ends with [Done] or [Cancel], its [ResultMsg] is delivered to
+// the atom beneath it, or returned as the result of the Chain if it was the
+// root.
+//
+// Pushed atoms are mounted, and atoms removed from the stack are disposed.
+// See [Mount] and [Dispose].
+type Chain struct {
+	Atom
+	stack []Atomic
+}
+
+// NewChain creates a new [Chain] with root at the bottom of the stack.
+func NewChain(name string, root Atomic) *Chain {
+	if root == nil {
+		panic("root atom cannot be nil")
+	}
+
+	return &Chain{
+		Atom:  NewAtom(name),
+		stack: []Atomic{root},
+	}
+}
+
+// PushMsg pushes an [Atomic] onto the [Chain].
+type PushMsg struct {
+	Atom Atomic
+}
+
+// PopMsg pops the top [Atomic] off the [Chain].
+type PopMsg struct{}
+
+// ReplaceMsg replaces the top [Atomic] of the [Chain].
+type ReplaceMsg struct {
+	Atom Atomic
+}
+
+// PopToRootMsg pops every [Atomic] off the [Chain] except the root.
+type PopToRootMsg struct{}
+
+// Push sends a [PushMsg].
+func Push(atom Atomic) tea.Cmd {
+	return func() tea.Msg {
+		return PushMsg{Atom: atom}
+	}
+}
+
+// Pop sends a [PopMsg].
+func Pop() tea.Cmd {
+	return func() tea.Msg {
+		return PopMsg{}
+	}
+}
+
+// Replace sends a [ReplaceMsg].
+func Replace(atom Atomic) tea.Cmd {
+	return func() tea.Msg {
+		return ReplaceMsg{Atom: atom}
+	}
+}
+
+// PopToRoot sends a [PopToRootMsg].
+func PopToRoot() tea.Cmd {
+	return func() tea.Msg {
+		return PopToRootMsg{}
+	}
+}
+
+// Depth returns the number of atoms on the stack.
+func (c Chain) Depth() int { return len(c.stack) }
+
+var _ Modal = Chain{}
+
+// GetCurrent implements [Modal].
+// It returns the top of the stack, or the Chain itself when the stack is empty.
+func (c Chain) GetCurrent() Atomic {
+	if len(c.stack) == 0 {
+		return c
+	}
+
+	return c.stack[len(c.stack)-1]
+}
+
+// Children implements [Container].
+// It returns every atom on the stack, from the root to the top.
+func (c Chain) Children() []tea.Model {
+	children := make([]tea.Model, len(c.stack))
+	for i, atom := range c.stack {
+		children[i] = atom
+	}
+	return children
+}
+
+var _ Snapshotter = Chain{}
+
+// Snapshot implements [Snapshotter].
+//
+// Only the root is part of the snapshot. Atoms pushed onto the Chain,
+// including routes opened with [Navigate], cannot be recreated from a
+// snapshot, so a restored Chain shows its root. Use [WithStartRoute] to
+// open a route when the Host starts.
+func (c Chain) Snapshot() ([]byte, error) {
+	if len(c.stack) == 0 {
+		return nil, nil
+	}
+
+	return SnapshotModel(c.stack[0])
+}
+
+// Restore implements [Snapshotter].
+func (c Chain) Restore(data []byte) (tea.Model, error) {
+	if len(c.stack) == 0 {
+		return c, nil
+	}
+
+	root, err := RestoreModel(c.stack[0], data)
+	if err != nil {
+		return c, err
+	}
+
+	c.stack = slices.Clone(c.stack)
+	c.stack[0] = AsAtomic(c.stack[0], root)
+	return c, nil
+}
+
+// Init implements [tea.Model].
+func (c Chain) Init() tea.Cmd {
+	if len(c.stack) == 0 {
+		return nil
+	}
+
+	return c.stack[len(c.stack)-1].Init()
+}
+
+// Update implements [tea.Model].
+func (c Chain) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case PushMsg:
+		if msg.Atom == nil {
+			return c, nil
+		}
+		c.stack = append(slices.Clone(c.stack), msg.Atom)
+		return c, tea.Batch(msg.Atom.Init(), Mount(msg.Atom))
+
+	case ReplaceMsg:
+		if msg.Atom == nil {
+			return c, nil
+		}
+		if len(c.stack) == 0 {
+			c.stack = []Atomic{msg.Atom}
+			return c, tea.Batch(msg.Atom.Init(), Mount(msg.Atom))
+		}
+		top := len(c.stack) - 1
+		removed := c.stack[top]
+		c.stack = append(slices.Clone(c.stack[:top]), msg.Atom)
+		return c, tea.Batch(Dispose(removed), msg.Atom.Init(), Mount(msg.Atom))
+
+	case PopMsg:
+		if len(c.stack) == 0 {
+			return nil, nil
+		}
+		top := len(c.stack) - 1
+		removed := c.stack[top]
+		c.stack = c.stack[:top]
+		if len(c.stack) == 0 {
+			return nil, nil
+		}
+		return c, Dispose(removed)
+
+	case PopToRootMsg:
+		if len(c.stack) == 0 {
+			return nil, nil
+		}
+		cmds := make([]tea.Cmd, 0, len(c.stack))
+		for i := len(c.stack) - 1; i > 0; i-- {
+			cmds = append(cmds, Dispose(c.stack[i]))
+		}
+		c.stack = c.stack[:1]
+		return c, tea.Batch(cmds...)
+	}
+
+	if len(c.stack) == 0 {
+		return nil, nil
+	}
+
+	if IsRouted(msg) {
+		return c.broadcast(msg)
+	}
+
+	top := len(c.stack) - 1
+	next, cmd := c.stack[top].Update(msg)
+	if result, ok := Exited(next); ok {
+		removed := c.stack[top]
+		c.stack = c.stack[:top]
+		if len(c.stack) == 0 {
+			return next, cmd
+		}
+		return c, tea.Batch(cmd, Dispose(removed), c.deliverResult(result))
+	}
+
+	c.stack = slices.Clone(c.stack)
+	c.stack[top] = AsAtomic(c.stack[top], next)
+	return c, cmd
+}
+
+// broadcast delivers msg to every atom on the stack, removing those that return nil.
+func (c Chain) broadcast(msg tea.Msg) (tea.Model, tea.Cmd) {
+	stack := make([]Atomic, 0, len(c.stack))
+	cmds := make([]tea.Cmd, 0, len(c.stack))
+	var results []tea.Model
+	for _, atom := range c.stack {
+		next, cmd := Deliver(atom, msg)
+		cmds = append(cmds, cmd)
+		if result, ok := Exited(next); ok {
+			if result != nil {
+				results = append(results, next)
+			}
+			continue
+		}
+		stack = append(stack, AsAtomic(atom, next))
+	}
+
+	if len(stack) == 0 {
+		if len(results) > 0 {
+			return results[len(results)-1], tea.Batch(cmds...)
+		}
+		return nil, tea.Batch(cmds...)
+	}
+
+	for _, atom := range c.stack {
+		if !slices.ContainsFunc(stack, func(a Atomic) bool { return a.Id() == atom.Id() }) {
+			cmds = append(cmds, Dispose(atom))
+		}
+	}
+
+	c.stack = stack
+	for _, next := range results {
+		result, _ := Exited(next)
+		cmds = append(cmds, c.deliverResult(result))
+	}
+
+	return c, tea.Batch(cmds...)
+}
+
+// deliverResult sends result to the top of the stack.
+func (c Chain) deliverResult(result tea.Msg) tea.Cmd {
+	if result == nil {
+		return nil
+	}
+
+	return SendTo(c.stack[len(c.stack)-1].Id(), result)
+}
+
+// View implements [tea.Model].
+func (c Chain) View() string {
+	if len(c.stack) == 0 {
+		return ""
+	}
+
+	return c.stack[len(c.stack)-1].View()
+}
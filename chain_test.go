@@ -0,0 +1,126 @@
+package polymer
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestChainPushPop(t *testing.T) {
+	root, screen := newProbe("root"), newProbe("screen")
+	chain := *NewChain("chain", root)
+
+	next, _ := chain.Update(PushMsg{Atom: screen})
+	pushed := next.(Chain)
+	if pushed.Depth() != 2 || pushed.GetCurrent().Id() != screen.Id() {
+		t.Fatalf("after push: depth %d, current %q", pushed.Depth(), pushed.GetCurrent().Name())
+	}
+	if chain.Depth() != 1 {
+		t.Errorf("push changed the original chain: depth %d", chain.Depth())
+	}
+
+	next, _ = pushed.Update(PopMsg{})
+	popped := next.(Chain)
+	if popped.Depth() != 1 || popped.GetCurrent().Id() != root.Id() {
+		t.Fatalf("after pop: depth %d, current %q", popped.Depth(), popped.GetCurrent().Name())
+	}
+
+	next, _ = popped.Update(PopMsg{})
+	if next != nil {
+		t.Errorf("popping the root returned %T, want nil", next)
+	}
+}
+
+func TestChainUpdateDoesNotAlias(t *testing.T) {
+	next, _ := NewChain("chain", newProbe("root")).Update(PushMsg{Atom: newProbe("screen")})
+	before := next.(Chain)
+
+	next, _ = before.Update("key")
+	after := next.(Chain)
+
+	if got := after.GetCurrent().(probe).updates; got != 1 {
+		t.Errorf("updated chain: top has %d updates, want 1", got)
+	}
+	if got := before.GetCurrent().(probe).updates; got != 0 {
+		t.Errorf("earlier copy: top has %d updates, want 0", got)
+	}
+}
+
+func TestChainDeliversResult(t *testing.T) {
+	root, screen := newProbe("root"), newProbe("screen")
+	next, _ := NewChain("chain", root).Update(PushMsg{Atom: screen})
+
+	next, cmd := next.Update(doneMsg("ok"))
+	chain := next.(Chain)
+	if chain.Depth() != 1 {
+		t.Fatalf("finished screen was not popped: depth %d", chain.Depth())
+	}
+
+	envs := envelopes(collect(cmd))
+	if len(envs) != 1 || envs[0].Target != root.Id() {
+		t.Fatalf("result envelopes = %+v, want one to the root", envs)
+	}
+
+	chain.Update(envs[0])
+	want := ResultMsg[string]{Source: screen.Id(), Value: "ok"}
+	if !root.got(want) {
+		t.Errorf("root received %v, want %v", *root.received, want)
+	}
+}
+
+func TestChainReturnsResultOfRoot(t *testing.T) {
+	root := newProbe("root")
+	next, _ := NewChain("chain", root).Update(doneMsg("ok"))
+
+	result, ok := Exited(next)
+	want := ResultMsg[string]{Source: root.Id(), Value: "ok"}
+	if !ok || result != want {
+		t.Errorf("Exited = %v, %v; want %v, true", result, ok, want)
+	}
+}
+
+// depth returns the depth of chain, whether or not it has been updated.
+func depth(chain tea.Model) int { return chain.(interface{ Depth() int }).Depth() }
+
+func TestHostPushesOntoFocusedChainOnly(t *testing.T) {
+	a, b := NewChain("a", newProbe("a root")), NewChain("b", newProbe("b root"))
+	h := *newHost("host", NewFocusGroup("group", a, b))
+
+	next, _ := h.Update(PushMsg{Atom: newProbe("screen")})
+	group := next.(Host).state.(FocusGroup)
+
+	if depth := depth(group.children[0]); depth != 2 {
+		t.Errorf("focused chain depth = %d, want 2", depth)
+	}
+	if depth := depth(group.children[1]); depth != 1 {
+		t.Errorf("sibling chain depth = %d, want 1", depth)
+	}
+	if err := CheckIds(group); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestChainPushesAddressedMessage(t *testing.T) {
+	a, b := NewChain("a", newProbe("a root")), NewChain("b", newProbe("b root"))
+	group := NewFocusGroup("group", a, b)
+
+	next, _ := group.Update(Envelope{Target: b.Id(), Msg: PushMsg{Atom: newProbe("screen")}})
+	children := next.(FocusGroup).children
+	if da, db := depth(children[0]), depth(children[1]); da != 1 || db != 2 {
+		t.Errorf("depths = %d, %d; want 1, 2", da, db)
+	}
+}
+
+func TestEmptyChainIgnoresPops(t *testing.T) {
+	for _, msg := range []any{PopMsg{}, PopToRootMsg{}} {
+		if next, _ := (Chain{}).Update(msg); next != nil {
+			t.Errorf("%T: empty chain returned %T, want nil", msg, next)
+		}
+	}
+
+	screen := newProbe("screen")
+	next, _ := (Chain{}).Update(ReplaceMsg{Atom: screen})
+	if chain := next.(Chain); chain.Depth() != 1 || chain.GetCurrent().Id() != screen.Id() {
+		t.Errorf("replace on an empty chain: depth %d", chain.Depth())
+	}
+}
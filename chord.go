@@ -0,0 +1,286 @@
+package polymer
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/trippwill/polymer/keymap"
+)
+
+// DefaultChordTimeout is how long the [Host] waits for the next key of a
+// sequence unless changed with [WithChordTimeout].
+const DefaultChordTimeout = time.Second
+
+// ActionMsg asks the active model to perform an action bound to a key
+// sequence. See [WithChords].
+type ActionMsg struct {
+	Action keymap.Action
+	Count  int // The count typed before the sequence, or 1.
+}
+
+// ActionHandler is implemented by models that perform actions sent as
+// [ActionMsg].
+type ActionHandler interface {
+	// HandlesAction reports whether the model performs action.
+	HandlesAction(action keymap.Action) bool
+}
+
+// WithChords makes the Host match key sequences bound to actions, such as
+// "g g" or "ctrl+x ctrl+s", and send an [ActionMsg] instead of the keys to
+// the deepest model of the [ActivePath] that is an [ActionHandler] for the
+// action. When no model handles the action the keys are delivered as usual.
+// A sequence is written as keys separated by spaces, with "space" for the
+// space bar; single keys are sequences too.
+//
+// Digits typed before a sequence, as in "5 j", set the Count of the
+// ActionMsg. While a sequence is incomplete its keys are held back and
+// shown in the bottom-right corner. Keys that do not complete a sequence
+// are delivered as usual, as are the held keys when the sequence times out.
+// A sequence that is also the start of a longer one is sent on timeout.
+//
+// While a [TextInput] on the active path accepts text, keys that type text
+// go to it directly, so digits and letters are not held back.
+func WithChords(actions ...keymap.Action) HostOption {
+	return func(h *Host) {
+		h.chords.actions = append(h.chords.actions, actions...)
+	}
+}
+
+// WithChordTimeout sets how long the Host waits for the next key of a
+// sequence, replacing [DefaultChordTimeout].
+func WithChordTimeout(d time.Duration) HostOption {
+	return func(h *Host) {
+		h.chords.timeout = d
+	}
+}
+
+// chords holds the key sequences of a Host and the keys typed so far.
+type chords struct {
+	actions    []keymap.Action
+	timeout    time.Duration
+	pending    []tea.KeyMsg // keys held back, the count digits first
+	digits     int          // number of pending keys that form the count
+	count      int          // count typed before the sequence, or 0
+	generation int          // identifies the most recent timeout
+}
+
+// chordTimeoutMsg ends the wait for the next key of a sequence.
+type chordTimeoutMsg struct {
+	generation int
+}
+
+var chordStyle = lipgloss.NewStyle().Reverse(true).Padding(0, 1)
+
+// sequence returns the keys of the pending sequence, without the count.
+func (c chords) sequence() []string {
+	sequence := make([]string, 0, len(c.pending)-c.digits)
+	for _, key := range c.pending[c.digits:] {
+		sequence = append(sequence, key.String())
+	}
+
+	return sequence
+}
+
+// match returns the action bound to sequence, if any, and whether the
+// sequence is the start of a longer one.
+func (c chords) match(sequence []string) (action keymap.Action, exact, longer bool) {
+	for _, a := range c.actions {
+		binding := a.Binding()
+		if !binding.Enabled() {
+			continue
+		}
+
+		for _, k := range binding.Keys() {
+			keys := splitSequence(k)
+			switch {
+			case slices.Equal(keys, sequence):
+				if !exact {
+					action, exact = a, true
+				}
+			case len(keys) > len(sequence) && slices.Equal(keys[:len(sequence)], sequence):
+				longer = true
+			}
+		}
+	}
+
+	return action, exact, longer
+}
+
+// wait holds key back and returns a command ending the wait after the timeout.
+func (c *chords) wait(key tea.KeyMsg) tea.Cmd {
+	c.pending = append(c.pending, key)
+	c.generation++
+
+	timeout, generation := c.timeout, c.generation
+	if timeout <= 0 {
+		timeout = DefaultChordTimeout
+	}
+
+	return tea.Tick(timeout, func(time.Time) tea.Msg {
+		return chordTimeoutMsg{generation: generation}
+	})
+}
+
+// reset clears the pending keys and returns them.
+func (c *chords) reset() []tea.KeyMsg {
+	pending := c.pending
+	c.pending, c.digits, c.count = nil, 0, 0
+	c.generation++
+	return pending
+}
+
+// String renders the pending keys for the indicator.
+func (c chords) String() string {
+	keys := c.sequence()
+	for i, k := range keys {
+		if k == " " {
+			keys[i] = "space"
+		}
+	}
+
+	if c.count > 0 {
+		keys = slices.Insert(keys, 0, strconv.Itoa(c.count))
+	}
+
+	return strings.Join(keys, " ")
+}
+
+// splitSequence splits a key sequence as written in a binding into keys.
+func splitSequence(sequence string) []string {
+	if sequence == " " {
+		return []string{" "}
+	}
+
+	keys := strings.Fields(sequence)
+	for i, k := range keys {
+		if k == "space" {
+			keys[i] = " "
+		}
+	}
+
+	return keys
+}
+
+// digit returns the value of key if it is a digit.
+func digit(key tea.KeyMsg) (int, bool) {
+	if key.Type != tea.KeyRunes || key.Alt || len(key.Runes) != 1 {
+		return 0, false
+	}
+
+	r := key.Runes[0]
+	if r < '0' || r > '9' {
+		return 0, false
+	}
+
+	return int(r - '0'), true
+}
+
+// updateChord matches key against the key sequences of the Host.
+func (h Host) updateChord(key tea.KeyMsg) (Host, tea.Cmd) {
+	c := &h.chords
+	if d, ok := digit(key); ok && len(c.pending) == c.digits && (d > 0 || c.count > 0) {
+		c.digits++
+		c.count = c.count*10 + d
+		return h, c.wait(key)
+	}
+
+	sequence := append(c.sequence(), key.String())
+	action, exact, longer := c.match(sequence)
+	switch {
+	case longer:
+		return h, c.wait(key)
+	case exact:
+		count := c.count
+		keys := append(c.reset(), key)
+		return h.dispatch(action, count, keys)
+	case len(c.pending) == 0:
+		return h.deliver(key)
+	}
+
+	// key does not continue the pending sequence: end it and start over.
+	h, cmd := h.flushChord()
+	if h.state == nil {
+		return h, cmd
+	}
+
+	h, next := h.updateChord(key)
+	return h, tea.Batch(cmd, next)
+}
+
+// chordTimeout ends the pending sequence if msg is its latest timeout.
+func (h Host) chordTimeout(msg chordTimeoutMsg) (Host, tea.Cmd) {
+	if msg.generation != h.chords.generation {
+		return h, nil
+	}
+
+	return h.flushChord()
+}
+
+// flushChord sends the action bound to the pending sequence, or delivers
+// the pending keys if it has none.
+func (h Host) flushChord() (Host, tea.Cmd) {
+	action, exact, _ := h.chords.match(h.chords.sequence())
+	count := h.chords.count
+	pending := h.chords.reset()
+	if exact {
+		return h.dispatch(action, count, pending)
+	}
+
+	return h.deliverKeys(pending)
+}
+
+// deliverKeys delivers keys to the tree in order, as if no sequence matched.
+func (h Host) deliverKeys(keys []tea.KeyMsg) (Host, tea.Cmd) {
+	var cmds []tea.Cmd
+	for _, key := range keys {
+		var cmd tea.Cmd
+		h, cmd = h.deliver(key)
+		cmds = append(cmds, cmd)
+		if h.state == nil {
+			break
+		}
+	}
+
+	return h, tea.Batch(cmds...)
+}
+
+// dispatch sends an [ActionMsg] to the model that handles action, or
+// delivers the keys typed for it if no model does.
+func (h Host) dispatch(action keymap.Action, count int, keys []tea.KeyMsg) (Host, tea.Cmd) {
+	handler, ok := h.actionHandler(action)
+	if !ok {
+		return h.deliverKeys(keys)
+	}
+
+	if count == 0 {
+		count = 1
+	}
+
+	return h.deliver(Envelope{Target: handler.Id(), Msg: ActionMsg{Action: action, Count: count}})
+}
+
+// actionHandler returns the deepest model of the active path that handles action.
+func (h Host) actionHandler(action keymap.Action) (Atomic, bool) {
+	path := ActivePath(h.state)
+	for i := len(path) - 1; i >= 0; i-- {
+		if handler, ok := path[i].(ActionHandler); ok && handler.HandlesAction(action) {
+			return path[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// viewChord renders the pending keys in the bottom-right corner of view.
+func (h Host) viewChord(view string) string {
+	if len(h.chords.pending) == 0 {
+		return view
+	}
+
+	indicator := chordStyle.Render(h.chords.String())
+	return Place(view, indicator, Anchored(lipgloss.Right, lipgloss.Bottom), h.width, h.height)
+}
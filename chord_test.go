@@ -0,0 +1,87 @@
+package polymer
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/trippwill/polymer/keymap"
+)
+
+var (
+	testRegistry = keymap.NewRegistry()
+	topAction    = testRegistry.Define("test", "top", key.NewBinding(key.WithKeys("g g")))
+	downAction   = testRegistry.Define("test", "down", key.NewBinding(key.WithKeys("j")))
+)
+
+// handler is a probe that handles the top action.
+type handler struct{ probe }
+
+func (h handler) HandlesAction(action keymap.Action) bool { return action == topAction }
+
+func (h handler) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := h.probe.Update(msg)
+	if p, ok := next.(probe); ok {
+		h.probe = p
+		return h, cmd
+	}
+	return next, cmd
+}
+
+// typist is a probe that accepts text.
+type typist struct{ probe }
+
+func (t typist) AcceptsText() bool { return true }
+
+func (t typist) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := t.probe.Update(msg)
+	if p, ok := next.(probe); ok {
+		t.probe = p
+		return t, cmd
+	}
+	return next, cmd
+}
+
+func runes(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+// press sends each key to h.
+func press(h Host, keys ...tea.KeyMsg) Host {
+	for _, k := range keys {
+		next, _ := h.Update(k)
+		h = next.(Host)
+	}
+	return h
+}
+
+func TestChordSendsActionToHandler(t *testing.T) {
+	leaf := handler{newProbe("leaf")}
+	h := press(*newHost("host", leaf, WithChords(topAction)), runes("3"), runes("g"), runes("g"))
+
+	if want := (ActionMsg{Action: topAction, Count: 3}); !leaf.got(want) {
+		t.Errorf("leaf received %v, want %v", *leaf.received, want)
+	}
+	if len(h.chords.pending) != 0 {
+		t.Errorf("keys still pending: %v", h.chords.pending)
+	}
+}
+
+func TestUnhandledChordDeliversKeys(t *testing.T) {
+	leaf := newProbe("leaf")
+	press(*newHost("host", leaf, WithChords(downAction)), runes("j"))
+
+	if !leaf.gotKey("j") {
+		t.Errorf("leaf received %v, want the key j", *leaf.received)
+	}
+}
+
+func TestTextInputTakesCountDigits(t *testing.T) {
+	leaf := typist{newProbe("leaf")}
+	h := press(*newHost("host", leaf, WithChords(topAction)), runes("5"))
+
+	if !leaf.gotKey("5") {
+		t.Errorf("leaf received %v, want the key 5", *leaf.received)
+	}
+	if len(h.chords.pending) != 0 {
+		t.Errorf("keys still pending: %v", h.chords.pending)
+	}
+}
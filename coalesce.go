@@ -0,0 +1,110 @@
+package polymer
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Debounce returns [Middleware] that holds back messages of type T until
+// none has arrived for d, then delivers the last one. Other messages pass
+// through unchanged.
+//
+// Each Debounce keeps its own state, so create one for each [Lens]. To
+// debounce resizing for a whole app:
+//
+//	poly.NewHost("app", root,
+//		poly.WithMiddleware(poly.Debounce[tea.WindowSizeMsg](50*time.Millisecond)),
+//	)
+func Debounce[T any](d time.Duration) Middleware {
+	c := &coalescer{}
+	return func(msg tea.Msg, next Next) tea.Cmd {
+		switch msg := msg.(type) {
+		case T:
+			c.latest = msg
+			c.generation++
+			return c.flushAfter(d)
+
+		case flushMsg:
+			if msg.owner != c {
+				break
+			}
+			if msg.generation != c.generation || c.latest == nil {
+				return nil
+			}
+			latest := c.latest
+			c.latest = nil
+			return next(latest)
+		}
+
+		return next(msg)
+	}
+}
+
+// Throttle returns [Middleware] that delivers at most one message of type
+// T every d. The first message is delivered at once; the last message held
+// back during each interval is delivered when it ends. Other messages pass
+// through unchanged.
+//
+// Each Throttle keeps its own state, so create one for each [Lens].
+func Throttle[T any](d time.Duration) Middleware {
+	c := &coalescer{}
+	return func(msg tea.Msg, next Next) tea.Cmd {
+		switch msg := msg.(type) {
+		case T:
+			if c.waiting {
+				c.latest = msg
+				return nil
+			}
+			c.waiting = true
+			c.generation++
+			return tea.Batch(next(msg), c.flushAfter(d))
+
+		case flushMsg:
+			if msg.owner != c {
+				break
+			}
+			if msg.generation != c.generation {
+				return nil
+			}
+			if c.latest == nil {
+				c.waiting = false
+				return nil
+			}
+			latest := c.latest
+			c.latest = nil
+			c.generation++
+			return tea.Batch(next(latest), c.flushAfter(d))
+		}
+
+		return next(msg)
+	}
+}
+
+// coalescer holds the state of a [Debounce] or [Throttle].
+type coalescer struct {
+	latest     tea.Msg // last message held back, or nil
+	generation int     // identifies the most recent flush
+	waiting    bool    // whether a throttle interval is running
+}
+
+// flushAfter returns a command sending a flushMsg for the current generation after d.
+func (c *coalescer) flushAfter(d time.Duration) tea.Cmd {
+	generation := c.generation
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return flushMsg{owner: c, generation: generation}
+	})
+}
+
+// flushMsg tells a coalescer its interval has ended.
+// It is delivered to every model so it reaches the [Lens] that owns it,
+// even when that Lens is not on the active path.
+type flushMsg struct {
+	owner      *coalescer
+	generation int
+}
+
+var _ Routed = flushMsg{}
+
+// RouteTo implements [Routed].
+func (m flushMsg) RouteTo(tea.Model) (tea.Msg, bool) { return m, true }
@@ -0,0 +1,106 @@
+package polymer
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recordNext returns a [Next] that records the messages it is passed.
+func recordNext() (Next, *[]tea.Msg) {
+	delivered := new([]tea.Msg)
+	return func(msg tea.Msg) tea.Cmd {
+		*delivered = append(*delivered, msg)
+		return nil
+	}, delivered
+}
+
+// flushOf returns the flushMsg sent by cmd.
+func flushOf(t *testing.T, cmd tea.Cmd) flushMsg {
+	t.Helper()
+	for _, msg := range collect(cmd) {
+		if flush, ok := msg.(flushMsg); ok {
+			return flush
+		}
+	}
+
+	t.Fatalf("command did not flush")
+	return flushMsg{}
+}
+
+func TestDebounceDeliversLast(t *testing.T) {
+	debounce := Debounce[int](time.Microsecond)
+	next, delivered := recordNext()
+
+	first := flushOf(t, debounce(1, next))
+	debounce(2, next)
+	last := flushOf(t, debounce(3, next))
+	debounce("other", next)
+	if want := []tea.Msg{"other"}; !slices.Equal(*delivered, want) {
+		t.Fatalf("before flushing delivered %v, want %v", *delivered, want)
+	}
+
+	debounce(first, next)
+	if len(*delivered) != 1 {
+		t.Errorf("stale flush delivered %v", (*delivered)[1:])
+	}
+
+	debounce(last, next)
+	debounce(last, next)
+	if want := []tea.Msg{"other", 3}; !slices.Equal(*delivered, want) {
+		t.Errorf("delivered %v, want %v", *delivered, want)
+	}
+}
+
+func TestThrottleDeliversFirstAndLast(t *testing.T) {
+	throttle := Throttle[int](time.Microsecond)
+	next, delivered := recordNext()
+
+	first := flushOf(t, throttle(1, next))
+	throttle(2, next)
+	throttle(3, next)
+	if want := []tea.Msg{1}; !slices.Equal(*delivered, want) {
+		t.Fatalf("during the interval delivered %v, want %v", *delivered, want)
+	}
+
+	second := flushOf(t, throttle(first, next))
+	if want := []tea.Msg{1, 3}; !slices.Equal(*delivered, want) {
+		t.Fatalf("after the interval delivered %v, want %v", *delivered, want)
+	}
+
+	throttle(4, next)
+	throttle(first, next)
+	if want := []tea.Msg{1, 3}; !slices.Equal(*delivered, want) {
+		t.Fatalf("stale flush delivered %v", (*delivered)[2:])
+	}
+
+	third := flushOf(t, throttle(second, next))
+	if cmd := throttle(third, next); cmd != nil {
+		t.Errorf("a quiet interval flushed again")
+	}
+	throttle(5, next)
+	if want := []tea.Msg{1, 3, 4, 5}; !slices.Equal(*delivered, want) {
+		t.Errorf("delivered %v, want %v", *delivered, want)
+	}
+}
+
+func TestDebounceFlushesThroughLensCopies(t *testing.T) {
+	root := newProbe("root")
+	lens := *NewLens(root, WithMiddleware(Debounce[int](time.Microsecond)))
+	other := *NewLens(newProbe("other"), WithMiddleware(Debounce[int](time.Microsecond)))
+
+	next, cmd := lens.Update(1)
+	flush := flushOf(t, cmd)
+	other.Update(flush)
+
+	// The updated copy of the Lens shares the state of its middleware.
+	next.Update(flush)
+	if !root.got(1) {
+		t.Errorf("root received %v, want 1", *root.received)
+	}
+	if got := other.Model.(probe); got.got(1) {
+		t.Errorf("another lens delivered the flush: %v", *got.received)
+	}
+}
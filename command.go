@@ -0,0 +1,81 @@
+package polymer
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Command is an action that can be run from a command palette.
+type Command struct {
+	Name        string
+	Description string
+	Binding     key.Binding // Keys that run the command directly, shown beside it.
+	Run         tea.Cmd
+}
+
+// CommandProvider is implemented by models that contribute commands while
+// they are active. See [Commands].
+type CommandProvider interface {
+	Commands() []Command
+}
+
+// Commands collects the commands of the models on the [ActivePath] of root
+// that implement [CommandProvider], outermost first.
+func Commands(root tea.Model) []Command {
+	var commands []Command
+	for _, atom := range ActivePath(root) {
+		if provider, ok := atom.(CommandProvider); ok {
+			commands = append(commands, provider.Commands()...)
+		}
+	}
+
+	return commands
+}
+
+// WithCommands registers commands that are available throughout the app.
+func WithCommands(commands ...Command) HostOption {
+	return func(h *Host) {
+		h.commands = append(h.commands, commands...)
+	}
+}
+
+// WithPalette opens the model returned by palette, given the [Commands] of
+// the Host and its active path, when the palette key is pressed: ctrl+p by
+// default, unless it types text into a [TextInput]. The palette is shown
+// over the tree as a dialog until it returns nil, and usually returns the
+// Run command of the chosen command with nil.
+func WithPalette[M tea.Model](palette func(commands []Command) M) HostOption {
+	return func(h *Host) {
+		h.palette = func(commands []Command) tea.Model { return palette(commands) }
+	}
+}
+
+var _ CommandProvider = Host{}
+
+// Commands implements [CommandProvider]. It returns the commands registered
+// with [WithCommands], the actions of [WithChords] handled on the active
+// path, which send an [ActionMsg] to their [ActionHandler], and the
+// commands of the active path.
+func (h Host) Commands() []Command {
+	commands := append([]Command(nil), h.commands...)
+	for _, action := range h.chords.actions {
+		handler, ok := h.actionHandler(action)
+		if !ok {
+			continue
+		}
+
+		binding := action.Binding()
+		name := binding.Help().Desc
+		if name == "" {
+			name = action.Name
+		}
+		commands = append(commands, Command{
+			Name:        name,
+			Description: action.String(),
+			Binding:     binding,
+			Run:         SendTo(handler.Id(), ActionMsg{Action: action, Count: 1}),
+		})
+	}
+
+	return append(commands, Commands(h.state)...)
+}
@@ -0,0 +1,35 @@
+package polymer
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openDialog shows dialog over the tree, sized to the window.
+func (h Host) openDialog(dialog tea.Model) (Host, tea.Cmd) {
+	h.dialog = dialog
+	init := dialog.Init()
+	h, size := h.updateDialog(tea.WindowSizeMsg{Width: h.width, Height: h.height})
+	return h, tea.Batch(init, size)
+}
+
+// updateDialog updates the dialog, closing it when it exits.
+// The result of a dialog that ends with [Done] or [Cancel] is discarded.
+func (h Host) updateDialog(msg tea.Msg) (Host, tea.Cmd) {
+	next, cmd := h.dialog.Update(msg)
+	if _, ok := Exited(next); ok {
+		h.dialog = nil
+		return h, cmd
+	}
+
+	h.dialog = next
+	return h, cmd
+}
+
+// viewDialog renders the dialog, if any, over view.
+func (h Host) viewDialog(view string) string {
+	if h.dialog == nil {
+		return view
+	}
+
+	return Place(view, h.dialog.View(), Centered, h.width, h.height)
+}
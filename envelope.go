@@ -0,0 +1,170 @@
+package polymer
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Routed is implemented by messages that containers deliver selectively.
+//
+// Containers deliver every message through [Route] or [Deliver], so a Routed
+// message decides which children receive it and in what form.
+type Routed interface {
+	// RouteTo returns the message to deliver to child, or false if child
+	// should not receive the message.
+	RouteTo(child tea.Model) (tea.Msg, bool)
+}
+
+// Route returns the message a container delivers to child.
+// Messages that are not [Routed] are delivered unchanged.
+func Route(msg tea.Msg, child tea.Model) (tea.Msg, bool) {
+	if routed, ok := msg.(Routed); ok {
+		return routed.RouteTo(child)
+	}
+
+	return msg, true
+}
+
+// IsRouted reports whether msg is [Routed].
+func IsRouted(msg tea.Msg) bool {
+	_, ok := msg.(Routed)
+	return ok
+}
+
+// Deliver updates child with msg as returned by [Route].
+// If child should not receive msg it is returned unchanged.
+func Deliver(child tea.Model, msg tea.Msg) (tea.Model, tea.Cmd) {
+	msg, ok := Route(msg, child)
+	if !ok {
+		return child, nil
+	}
+
+	return child.Update(msg)
+}
+
+// Envelope addresses a message to a single [Atomic] by id or name.
+//
+// Containers forward an Envelope only along the path to the child it is
+// addressed to, and unwrap it for that child, so models that are not on
+// the path never see it. Bubbling envelopes are forwarded, still wrapped,
+// only to the containers above their Source.
+type Envelope struct {
+	Target uint32  // Id of the recipient, or 0 to match by Name.
+	Name   string  // Name of the recipient when Target is 0.
+	Source uint32  // Id of the sender, or 0 if unknown.
+	Bubble bool    // Deliver to the containers above Source only, or to every container if Source is 0.
+	Msg    tea.Msg // The wrapped message.
+}
+
+// Send sends env.
+func Send(env Envelope) tea.Cmd {
+	return func() tea.Msg {
+		return env
+	}
+}
+
+// SendTo sends msg to the [Atomic] with the given id.
+func SendTo(target uint32, msg tea.Msg) tea.Cmd {
+	return Send(Envelope{Target: target, Msg: msg})
+}
+
+// SendToName sends msg to the [Atomic] with the given name.
+func SendToName(name string, msg tea.Msg) tea.Cmd {
+	return Send(Envelope{Name: name, Msg: msg})
+}
+
+// Reply sends msg from the sender to the source of env.
+func Reply(sender HasId, env Envelope, msg tea.Msg) tea.Cmd {
+	return Send(Envelope{Target: env.Source, Source: sender.Id(), Msg: msg})
+}
+
+// Bubble sends msg from the sender to the containers above it rather than to
+// a single target. Containers that handle msg receive the [Envelope] and can
+// tell senders apart by its Source.
+func Bubble(sender HasId, msg tea.Msg) tea.Cmd {
+	return Send(Envelope{Source: sender.Id(), Bubble: true, Msg: msg})
+}
+
+var _ Routed = Envelope{}
+
+// RouteTo implements [Routed].
+func (e Envelope) RouteTo(child tea.Model) (tea.Msg, bool) {
+	if e.Bubble {
+		return e, isContainer(child) && e.encloses(child)
+	}
+
+	if e.addresses(child) {
+		return e.Msg, true
+	}
+
+	if isContainer(child) && e.reachable(child) {
+		return e, true
+	}
+
+	return nil, false
+}
+
+// reachable reports whether the recipient of the Envelope may be below container.
+// Models that are not [Container] types are assumed to forward messages.
+func (e Envelope) reachable(container tea.Model) bool {
+	if _, ok := container.(Container); !ok {
+		return true
+	}
+
+	if e.Target != 0 {
+		return Contains(container, e.Target)
+	}
+
+	_, ok := FindByName(container, e.Name)
+	return ok
+}
+
+// encloses reports whether the source of the Envelope may be below container.
+// Models that are not [Container] types are assumed to forward messages.
+func (e Envelope) encloses(container tea.Model) bool {
+	if atom, ok := container.(HasId); ok && e.Source != 0 && atom.Id() == e.Source {
+		return false
+	}
+
+	if _, ok := container.(Container); !ok || e.Source == 0 {
+		return true
+	}
+
+	return Contains(container, e.Source)
+}
+
+// addresses reports whether the Envelope is addressed to model.
+func (e Envelope) addresses(model tea.Model) bool {
+	if e.Target != 0 {
+		atom, ok := model.(HasId)
+		return ok && atom.Id() == e.Target
+	}
+
+	if e.Name != "" {
+		atom, ok := model.(HasName)
+		return ok && atom.Name() == e.Name
+	}
+
+	return false
+}
+
+// Open returns the message wrapped in msg if msg is an [Envelope] carrying a T.
+func Open[T any](msg tea.Msg) (T, Envelope, bool) {
+	env, ok := msg.(Envelope)
+	if !ok {
+		var zero T
+		return zero, env, false
+	}
+
+	inner, ok := env.Msg.(T)
+	return inner, env, ok
+}
+
+// isContainer reports whether model may hold other models.
+func isContainer(model tea.Model) bool {
+	switch model.(type) {
+	case Modal, Container:
+		return true
+	default:
+		return false
+	}
+}
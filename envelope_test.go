@@ -0,0 +1,119 @@
+package polymer
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRoute(t *testing.T) {
+	a, b := newProbe("a"), newProbe("b")
+	group := *NewFocusGroup("group", a)
+	other := *NewFocusGroup("other", b)
+	tree := *NewFocusGroup("tree", group, other)
+
+	tests := []struct {
+		name  string
+		msg   tea.Msg
+		child tea.Model
+		want  tea.Msg // nil if the child does not receive the message
+	}{
+		{"plain message", "tick", a, "tick"},
+		{"to target", Envelope{Target: a.Id(), Msg: "hi"}, a, "hi"},
+		{"to other leaf", Envelope{Target: a.Id(), Msg: "hi"}, b, nil},
+		{"through container", Envelope{Target: a.Id(), Msg: "hi"}, group, Envelope{Target: a.Id(), Msg: "hi"}},
+		{"past container", Envelope{Target: a.Id(), Msg: "hi"}, other, nil},
+		{"to name", Envelope{Name: "b", Msg: "hi"}, b, "hi"},
+		{"name through container", Envelope{Name: "b", Msg: "hi"}, other, Envelope{Name: "b", Msg: "hi"}},
+		{"name past container", Envelope{Name: "b", Msg: "hi"}, group, nil},
+		{"bubble to ancestor", Envelope{Source: a.Id(), Bubble: true, Msg: "up"}, tree, Envelope{Source: a.Id(), Bubble: true, Msg: "up"}},
+		{"bubble to parent", Envelope{Source: a.Id(), Bubble: true, Msg: "up"}, group, Envelope{Source: a.Id(), Bubble: true, Msg: "up"}},
+		{"bubble past sibling", Envelope{Source: a.Id(), Bubble: true, Msg: "up"}, other, nil},
+		{"bubble past source", Envelope{Source: group.Id(), Bubble: true, Msg: "up"}, group, nil},
+		{"bubble past leaf", Envelope{Source: a.Id(), Bubble: true, Msg: "up"}, b, nil},
+		{"bubble without source", Envelope{Bubble: true, Msg: "up"}, other, Envelope{Bubble: true, Msg: "up"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Route(tt.msg, tt.child)
+			if ok != (tt.want != nil) || (ok && got != tt.want) {
+				t.Errorf("Route = %v, %v; want %v", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeliverEnvelope(t *testing.T) {
+	a, b := newProbe("a"), newProbe("b")
+	tree := NewFocusGroup("tree", NewFocusGroup("group", a), NewFocusGroup("other", b))
+
+	tree.Update(Envelope{Target: a.Id(), Msg: "direct"})
+	if !a.got("direct") || b.got("direct") {
+		t.Errorf("direct envelope reached a: %v, b: %v", a.got("direct"), b.got("direct"))
+	}
+
+	tree.Update(Envelope{Name: "b", Msg: "named"})
+	if a.got("named") || !b.got("named") {
+		t.Errorf("named envelope reached a: %v, b: %v", a.got("named"), b.got("named"))
+	}
+
+	tree.Update("broadcast")
+	if !a.got("broadcast") || !b.got("broadcast") {
+		t.Errorf("broadcast reached a: %v, b: %v", a.got("broadcast"), b.got("broadcast"))
+	}
+
+	bubble := Envelope{Source: a.Id(), Bubble: true, Msg: "bubble"}
+	tree.Update(bubble)
+	if a.got(bubble) || b.got(bubble) {
+		t.Errorf("bubble reached a leaf: a: %v, b: %v", a.got(bubble), b.got(bubble))
+	}
+}
+
+func TestBubbleReachesAncestorsOnly(t *testing.T) {
+	a, b := newProbe("a"), newProbe("b")
+	group := NewFocusGroup("group", a)
+	outer := newRecorder(group)
+	sibling := newRecorder(b)
+	tree := NewFocusGroup("tree", outer, sibling)
+
+	bubble := Envelope{Source: group.Id(), Bubble: true, Msg: "bubble"}
+	tree.Update(bubble)
+	if !outer.got(bubble) {
+		t.Errorf("bubble did not reach the container above its source")
+	}
+	if sibling.got(bubble) {
+		t.Errorf("bubble reached a container beside its source")
+	}
+}
+
+// recorder is a container of one child that records the messages it receives.
+type recorder struct {
+	Atom
+	child    Atomic
+	received *[]tea.Msg
+}
+
+func newRecorder(child Atomic) recorder {
+	return recorder{Atom: NewAtom("recorder"), child: child, received: new([]tea.Msg)}
+}
+
+func (r recorder) Children() []tea.Model { return []tea.Model{r.child} }
+
+func (r recorder) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	*r.received = append(*r.received, msg)
+	next, cmd := Deliver(r.child, msg)
+	r.child = AsAtomic(r.child, next)
+	return r, cmd
+}
+
+func (r recorder) View() string { return r.child.View() }
+
+func (r recorder) got(msg tea.Msg) bool {
+	for _, m := range *r.received {
+		if m == msg {
+			return true
+		}
+	}
+	return false
+}
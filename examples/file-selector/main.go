@@ -8,6 +8,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	poly "github.com/trippwill/polymer"
+	"github.com/trippwill/polymer/gels/breadcrumb"
 	"github.com/trippwill/polymer/gels/file"
 	"github.com/trippwill/polymer/gels/menu"
 	"github.com/trippwill/polymer/trace"
@@ -27,36 +28,41 @@ func NewSelectionHandler(title string, items ...menu.Item) *SelectionHandler {
 	}
 }
 
-var _ poly.Modal = (*SelectionHandler)(nil)
+var _ poly.Container = (*SelectionHandler)(nil)
 
-// GetCurrent implements [polymer.Modal].
-func (s *SelectionHandler) GetCurrent() poly.Atomic {
-	if s.lastSelection != "" {
-		return s
-	}
-
-	if m, ok := s.state.(poly.Atomic); ok {
-		return m
-	}
+// Children implements [polymer.Container].
+func (s *SelectionHandler) Children() []tea.Model {
+	return []tea.Model{s.state}
+}
 
-	return nil
+// AcceptsText forwards to the menu, so typing into its filter is not
+// taken for shortcuts.
+func (s *SelectionHandler) AcceptsText() bool {
+	input, ok := s.state.(poly.TextInput)
+	return ok && input.AcceptsText()
 }
 
 func (s *SelectionHandler) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case file.FileSelectionMsg:
-		// Handle file selection results
-		log.Default().Printf("File selection result: %v, %v", msg.Type, msg.Files)
-		if len(msg.Files) == 1 {
-			s.lastSelection = fmt.Sprintf("Selected %v: %s", msg.Type, msg.Files[0])
+	if result, ok := poly.OpenResult[file.Selection](msg); ok {
+		// Handle file selection results bubbled up from the menu
+		if result.Cancelled {
+			log.Default().Printf("File selection from [%d] cancelled", result.Source)
+			s.lastSelection = "Selection cancelled"
+			return s, nil
+		}
+
+		selection := result.Value
+		log.Default().Printf("File selection result from [%d]: %v, %v", result.Source, selection.Type, selection.Files)
+		if len(selection.Files) == 1 {
+			s.lastSelection = fmt.Sprintf("Selected %v: %s", selection.Type, selection.Files[0])
 		} else {
-			s.lastSelection = fmt.Sprintf("Selected %d %v: %s", len(msg.Files), msg.Type, strings.Join(msg.Files, ", "))
+			s.lastSelection = fmt.Sprintf("Selected %d %v: %s", len(selection.Files), selection.Type, strings.Join(selection.Files, ", "))
 		}
 		return s, trace.TraceInfo(s.lastSelection)
 	}
 
 	next, cmd := s.state.Update(msg)
-	if next == nil {
+	if _, ok := poly.Exited(next); ok {
 		return nil, tea.Quit
 	}
 
@@ -136,7 +142,7 @@ func main() {
 	// Create the host and start the Bubble Tea program
 	host := poly.NewHost(
 		"File Selector Example",
-		root,
+		breadcrumb.New(root),
 		poly.WithLifecycleLogging(logger)...,
 	)
 
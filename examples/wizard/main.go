@@ -1,14 +1,16 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
 	poly "github.com/trippwill/polymer"
+	"github.com/trippwill/polymer/gels/help"
 	"github.com/trippwill/polymer/gels/menu"
-	"github.com/trippwill/polymer/trace"
+	"github.com/trippwill/polymer/gels/palette"
 )
 
 // QuitAtom is a simple Atom that quits the application immediately.
@@ -28,6 +30,10 @@ type NamePromptScreen struct {
 
 func (n NamePromptScreen) Init() tea.Cmd { return nil }
 
+// AcceptsText lets the prompt take keys such as ? that the host would
+// otherwise use as shortcuts.
+func (n NamePromptScreen) AcceptsText() bool { return true }
+
 func (n NamePromptScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -41,18 +47,29 @@ func (n NamePromptScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return n, nil
 		case tea.KeyEnter:
-			return GreetingScreen{Value: n.input}, nil
+			// Exit the prompt and push the greeting onto the chain.
+			return nil, poly.Push(GreetingScreen{Atom: poly.NewAtom("Greeting"), Value: n.input})
 		}
 	}
 	return n, nil
 }
 
+// Snapshot saves the name entered so far, so it survives a restart.
+func (n NamePromptScreen) Snapshot() ([]byte, error) { return json.Marshal(n.input) }
+
+// Restore restores the name entered in a previous run.
+func (n NamePromptScreen) Restore(data []byte) (tea.Model, error) {
+	err := json.Unmarshal(data, &n.input)
+	return n, err
+}
+
 func (n NamePromptScreen) View() string {
 	return "Enter your name: " + n.input + "\n"
 }
 
 // GreetingScreen is an Atom that greets the user by name.
 type GreetingScreen struct {
+	poly.Atom
 	Value string
 }
 
@@ -61,9 +78,9 @@ func (g GreetingScreen) Init() tea.Cmd {
 }
 
 func (g GreetingScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	// On any key, reset chain to initial state: exit greeting and name prompt back to main menu.
+	// On any key, pop the greeting off the chain and return to the main menu.
 	if _, ok := msg.(tea.KeyMsg); ok {
-		return nil, nil
+		return g, poly.Pop()
 	}
 	return g, nil
 }
@@ -72,8 +89,6 @@ func (g GreetingScreen) View() string {
 	return "\nHello, " + g.Value + "! (press any key to return)\n"
 }
 
-func (g GreetingScreen) Name() string { return "Greeting" }
-
 func main() {
 	// Set up a standard logger for tracing
 	f, err := tea.LogToFile("debug.log", "debug")
@@ -86,17 +101,38 @@ func main() {
 
 	// Create the main menu using the menu gel
 	// Wrap the menu in its own navigation chain
-	root := menu.NewMenu(
+	root := poly.NewChain("Wizard", menu.NewMenu(
 		"Main Menu",
 		menu.NewItem(NamePromptScreen{Atom: poly.NewAtom("Enter Name")}, "Run the Name Wizard"),
 		menu.NewItem(QuitAtom{Atom: poly.NewAtom("Quit")}, "Exit Application"),
-	)
+	))
+
+	// Register routes so screens can be reached by name,
+	// e.g. `go run main.go greeting name=Ada`
+	router := poly.NewRouter().
+		Register("name", func(poly.Params) poly.Atomic {
+			return NamePromptScreen{Atom: poly.NewAtom("Enter Name")}
+		}).
+		Register("greeting", func(params poly.Params) poly.Atomic {
+			return GreetingScreen{Atom: poly.NewAtom("Greeting"), Value: params.Get("name")}
+		})
 
 	// Create the host and start the Bubble Tea program
-	host := poly.NewHost(
+	host := poly.NewHostWith(
 		"Polymer Integration Example",
 		root,
-		poly.WithLifecycleLogging(logger, trace.LevelTrace)...,
+		poly.WithRouter(router),
+		poly.WithStartRoute(os.Args[1:]),
+		poly.WithSnapshotFile("wizard-state.json"),
+		poly.WithKeymapFile("wizard-keys.json"),
+		poly.WithHelp(help.New),
+		poly.WithPalette(palette.New),
+		poly.WithCommands(poly.Command{
+			Name:        "Greet the world",
+			Description: "Open the greeting for World",
+			Run:         poly.Navigate("greeting", poly.Params{"name": "World"}),
+		}),
+		poly.WithLens(poly.WithLifecycleLogging(logger)...),
 	)
 
 	p := tea.NewProgram(host)
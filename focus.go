@@ -0,0 +1,325 @@
+package polymer
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/trippwill/polymer/util"
+)
+
+// Focusable is implemented by models that track keyboard focus.
+//
+// Focus and Blur return the updated model.
+type Focusable interface {
+	Focus() tea.Model
+	Blur() tea.Model
+	Focused() bool
+}
+
+// TextInput is implemented by models that take typed text, such as prompts
+// and forms. While a TextInput on the [ActivePath] accepts text, the [Host]
+// delivers keys that type text to the tree rather than treating them as
+// shortcuts, such as ? for help.
+type TextInput interface {
+	AcceptsText() bool
+}
+
+// FocusMsg moves focus to the [Atomic] with the given Id.
+// It is also delivered to that Atomic once it has gained focus.
+type FocusMsg struct {
+	Id uint32
+}
+
+// BlurMsg is delivered to the [Atomic] with the given Id after it has lost focus.
+type BlurMsg struct {
+	Id uint32
+}
+
+// Focus sends a [FocusMsg] for id.
+func Focus(id uint32) tea.Cmd {
+	return util.Broadcast(FocusMsg{Id: id})
+}
+
+// FocusGroup delivers key messages only to its focused child, and all other
+// messages to every child. Children are rendered top to bottom.
+//
+// Tab and Shift+Tab cycle focus between children. A child that returns nil
+// from Update is removed; the FocusGroup returns nil when no children remain.
+// A child that ends with [Done] or [Cancel] is removed too, and its
+// [ResultMsg] is bubbled to the containers above the FocusGroup. When it is
+// the last child, the FocusGroup ends with its result instead.
+type FocusGroup struct {
+	Atom
+	children []Atomic
+	focused  int
+	ended    tea.Model // returned from Update once no children remain
+}
+
+// NewFocusGroup creates a new [FocusGroup] with focus on the first child.
+func NewFocusGroup(name string, children ...Atomic) *FocusGroup {
+	if len(children) == 0 {
+		panic("focus group requires at least one child")
+	}
+
+	g := &FocusGroup{
+		Atom:     NewAtom(name),
+		children: slices.Clone(children),
+	}
+	g.children[0] = focusAtom(g.children[0])
+	return g
+}
+
+var _ Modal = FocusGroup{}
+
+// GetCurrent implements [Modal].
+// It returns the focused child.
+func (g FocusGroup) GetCurrent() Atomic {
+	if len(g.children) == 0 {
+		return g
+	}
+
+	return g.children[g.focused]
+}
+
+var _ KeyMapProvider = FocusGroup{}
+
+// KeyMap implements [KeyMapProvider].
+func (g FocusGroup) KeyMap() []key.Binding {
+	return []key.Binding{nextFocusKey.Binding(), previousFocusKey.Binding()}
+}
+
+// Children implements [Container].
+func (g FocusGroup) Children() []tea.Model {
+	children := make([]tea.Model, len(g.children))
+	for i, child := range g.children {
+		children[i] = child
+	}
+	return children
+}
+
+// focusSnapshot is the snapshot of a [FocusGroup].
+type focusSnapshot struct {
+	Focused  int               `json:"focused"`
+	Children []json.RawMessage `json:"children"`
+}
+
+var _ Snapshotter = FocusGroup{}
+
+// Snapshot implements [Snapshotter].
+func (g FocusGroup) Snapshot() ([]byte, error) {
+	snapshot := focusSnapshot{Focused: g.focused}
+	for _, child := range g.children {
+		data, err := SnapshotModel(child)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Children = append(snapshot.Children, data)
+	}
+
+	return json.Marshal(snapshot)
+}
+
+// Restore implements [Snapshotter].
+func (g FocusGroup) Restore(data []byte) (tea.Model, error) {
+	return g.restore(data)
+}
+
+// restore returns the FocusGroup restored from data.
+// Children are matched by position; extra snapshots are ignored.
+func (g FocusGroup) restore(data []byte) (FocusGroup, error) {
+	var snapshot focusSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return g, err
+	}
+
+	children := slices.Clone(g.children)
+	for i, child := range children {
+		if i >= len(snapshot.Children) {
+			break
+		}
+
+		restored, err := RestoreModel(child, snapshot.Children[i])
+		if err != nil {
+			return g, err
+		}
+		children[i] = AsAtomic(child, restored)
+	}
+
+	g.children = children
+	if snapshot.Focused != g.focused && snapshot.Focused >= 0 && snapshot.Focused < len(g.children) {
+		g.children[g.focused] = blurAtom(g.children[g.focused])
+		g.focused = snapshot.Focused
+		g.children[g.focused] = focusAtom(g.children[g.focused])
+	}
+
+	return g, nil
+}
+
+// Init implements [tea.Model].
+func (g FocusGroup) Init() tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(g.children)+1)
+	for _, child := range g.children {
+		cmds = append(cmds, child.Init())
+	}
+
+	return tea.Batch(append(cmds, Focus(g.children[g.focused].Id()))...)
+}
+
+// Update implements [tea.Model].
+func (g FocusGroup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	g, cmd = g.update(msg)
+	if len(g.children) == 0 {
+		return g.ended, cmd
+	}
+
+	return g, cmd
+}
+
+func (g FocusGroup) update(msg tea.Msg) (FocusGroup, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case nextFocusKey.Matches(msg):
+			return g, g.cycle(1)
+		case previousFocusKey.Matches(msg):
+			return g, g.cycle(-1)
+		}
+		return g.updateChild(g.focused, msg)
+
+	case FocusMsg:
+		if i := g.indexOf(msg.Id); i >= 0 {
+			cmd := g.moveFocus(i)
+			g, childCmd := g.updateChild(i, msg)
+			return g, tea.Batch(cmd, childCmd)
+		}
+
+	case BlurMsg:
+		if i := g.indexOf(msg.Id); i >= 0 {
+			return g.updateChild(i, msg)
+		}
+	}
+
+	var cmds []tea.Cmd
+	for i := 0; i < len(g.children); {
+		count := len(g.children)
+		var cmd tea.Cmd
+		g, cmd = g.deliverChild(i, msg)
+		cmds = append(cmds, cmd)
+		if len(g.children) == count {
+			i++
+		}
+	}
+
+	return g, tea.Batch(cmds...)
+}
+
+// deliverChild delivers msg to the child at i as returned by [Route].
+func (g FocusGroup) deliverChild(i int, msg tea.Msg) (FocusGroup, tea.Cmd) {
+	msg, ok := Route(msg, g.children[i])
+	if !ok {
+		return g, nil
+	}
+
+	return g.updateChild(i, msg)
+}
+
+// updateChild delivers msg to the child at i, removing it if it returns nil.
+func (g FocusGroup) updateChild(i int, msg tea.Msg) (FocusGroup, tea.Cmd) {
+	if i < 0 || i >= len(g.children) {
+		return g, nil
+	}
+
+	next, cmd := g.children[i].Update(msg)
+	result, exited := Exited(next)
+	if !exited {
+		g.children = slices.Clone(g.children)
+		g.children[i] = AsAtomic(g.children[i], next)
+		return g, cmd
+	}
+
+	removed := g.children[i]
+	g.children = slices.Delete(slices.Clone(g.children), i, i+1)
+	if len(g.children) == 0 {
+		g.ended = next
+		return g, cmd
+	}
+
+	cmd = tea.Batch(cmd, Dispose(removed))
+	if result != nil {
+		cmd = tea.Batch(cmd, Bubble(g, result))
+	}
+
+	switch {
+	case i < g.focused:
+		g.focused--
+	case i == g.focused:
+		g.focused = min(g.focused, len(g.children)-1)
+		return g, tea.Batch(cmd, Focus(g.children[g.focused].Id()))
+	}
+
+	return g, cmd
+}
+
+// cycle returns a command focusing the child delta positions away from the focused child.
+func (g FocusGroup) cycle(delta int) tea.Cmd {
+	n := len(g.children)
+	if n < 2 {
+		return nil
+	}
+
+	next := ((g.focused+delta)%n + n) % n
+	return Focus(g.children[next].Id())
+}
+
+// moveFocus focuses the child at i and blurs the previously focused child.
+func (g *FocusGroup) moveFocus(i int) tea.Cmd {
+	g.children = slices.Clone(g.children)
+	if i == g.focused {
+		g.children[i] = focusAtom(g.children[i])
+		return nil
+	}
+
+	previous := g.children[g.focused]
+	g.children[g.focused] = blurAtom(previous)
+	g.children[i] = focusAtom(g.children[i])
+	g.focused = i
+	return util.Broadcast(BlurMsg{Id: previous.Id()})
+}
+
+func (g FocusGroup) indexOf(id uint32) int {
+	return slices.IndexFunc(g.children, func(child Atomic) bool {
+		return child.Id() == id
+	})
+}
+
+// View implements [tea.Model].
+func (g FocusGroup) View() string {
+	views := make([]string, len(g.children))
+	for i, child := range g.children {
+		views[i] = child.View()
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, views...)
+}
+
+// focusAtom focuses atom if it is [Focusable].
+func focusAtom(atom Atomic) Atomic {
+	if f, ok := atom.(Focusable); ok && !f.Focused() {
+		return AsAtomic(atom, f.Focus())
+	}
+
+	return atom
+}
+
+// blurAtom blurs atom if it is [Focusable].
+func blurAtom(atom Atomic) Atomic {
+	if f, ok := atom.(Focusable); ok && f.Focused() {
+		return AsAtomic(atom, f.Blur())
+	}
+
+	return atom
+}
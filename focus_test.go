@@ -0,0 +1,55 @@
+package polymer
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFocusGroupRemovesFinishedChildren(t *testing.T) {
+	a, b := newProbe("a"), newProbe("b")
+	group := *NewFocusGroup("group", a, b)
+
+	next, cmd := group.Update(Envelope{Target: a.Id(), Msg: doneMsg("a")})
+	first := next.(FocusGroup)
+	if len(first.children) != 1 || first.GetCurrent().Id() != b.Id() {
+		t.Fatalf("after a finished: %d children, current %q", len(first.children), first.GetCurrent().Name())
+	}
+
+	envs := envelopes(collect(cmd))
+	want := ResultMsg[string]{Source: a.Id(), Value: "a"}
+	if len(envs) != 1 || !envs[0].Bubble || envs[0].Msg != want {
+		t.Errorf("bubbled %+v, want %v", envs, want)
+	}
+
+	next, _ = first.Update(doneMsg("b"))
+	result, ok := Exited(next)
+	if want := (ResultMsg[string]{Source: b.Id(), Value: "b"}); !ok || result != want {
+		t.Errorf("last child: Exited = %v, %v; want %v, true", result, ok, want)
+	}
+
+	if len(group.children) != 2 || group.children[0].Id() != a.Id() || group.children[1].Id() != b.Id() {
+		t.Errorf("removing children changed the original group: %v", group.Children())
+	}
+}
+
+func TestFocusGroupUpdateDoesNotAlias(t *testing.T) {
+	group := *NewFocusGroup("group", newProbe("a"), newProbe("b"))
+	key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}
+
+	next, _ := group.Update(key)
+	if got := next.(FocusGroup).GetCurrent().(probe).updates; got != 1 {
+		t.Errorf("updated group: focused child has %d updates, want 1", got)
+	}
+	if got := group.GetCurrent().(probe).updates; got != 0 {
+		t.Errorf("original group: focused child has %d updates, want 0", got)
+	}
+}
+
+func TestFocusGroupEndsWhenChildrenQuit(t *testing.T) {
+	a := newProbe("a")
+	next, _ := NewFocusGroup("group", a).Update(quitMsg{})
+	if next != nil {
+		t.Errorf("group with no children returned %T, want nil", next)
+	}
+}
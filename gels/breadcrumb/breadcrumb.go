@@ -0,0 +1,111 @@
+// Package breadcrumb renders the trail of active Atoms above a wrapped model.
+package breadcrumb
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	poly "github.com/trippwill/polymer"
+)
+
+// DefaultSeparator is placed between the names in the trail.
+const DefaultSeparator = " › "
+
+var (
+	crumbStyle   = lipgloss.NewStyle().Faint(true)
+	currentStyle = lipgloss.NewStyle().Bold(true)
+)
+
+// Render renders path as a single line of names separated by separator.
+func Render(path []poly.Atomic, separator string) string {
+	names := make([]string, len(path))
+	for i, atom := range path {
+		if i == len(path)-1 {
+			names[i] = currentStyle.Render(atom.Name())
+		} else {
+			names[i] = crumbStyle.Render(atom.Name())
+		}
+	}
+
+	return strings.Join(names, crumbStyle.Render(separator))
+}
+
+// Breadcrumb renders the active path of its model above the model's view.
+type Breadcrumb struct {
+	poly.Atom
+	model     tea.Model
+	wrapped   poly.Atomic // name and id of a model that is not Atomic
+	separator string
+}
+
+// New wraps model in a Breadcrumb.
+func New(model tea.Model) *Breadcrumb {
+	if model == nil {
+		panic("model cannot be nil")
+	}
+
+	return &Breadcrumb{
+		Atom:      poly.NewAtom("breadcrumb"),
+		model:     model,
+		wrapped:   poly.NewAtomicProxy("Wrapped"),
+		separator: DefaultSeparator,
+	}
+}
+
+// SetSeparator sets the separator placed between the names in the trail.
+func (b *Breadcrumb) SetSeparator(separator string) {
+	b.separator = separator
+}
+
+// Path returns the active path of the wrapped model.
+func (b Breadcrumb) Path() []poly.Atomic {
+	return poly.ActivePath(b.model)
+}
+
+var _ poly.Modal = Breadcrumb{}
+
+// GetCurrent implements [poly.Modal]. It returns the wrapped model, with
+// the same id on every call if it is not [poly.Atomic].
+func (b Breadcrumb) GetCurrent() poly.Atomic {
+	return poly.AsAtomic(b.wrapped, b.model)
+}
+
+var _ poly.Container = Breadcrumb{}
+
+func (b Breadcrumb) Children() []tea.Model { return []tea.Model{b.GetCurrent()} }
+
+var _ poly.Snapshotter = Breadcrumb{}
+
+func (b Breadcrumb) Snapshot() ([]byte, error) { return poly.SnapshotModel(b.model) }
+
+func (b Breadcrumb) Restore(data []byte) (tea.Model, error) {
+	model, err := poly.RestoreModel(b.model, data)
+	if err != nil {
+		return b, err
+	}
+
+	b.model = model
+	return b, nil
+}
+
+func (b Breadcrumb) Init() tea.Cmd { return b.model.Init() }
+
+func (b Breadcrumb) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		size.Height = max(0, size.Height-1) // Leave space for the trail
+		msg = size
+	}
+
+	next, cmd := poly.Deliver(b.model, msg)
+	if _, ok := poly.Exited(next); ok {
+		return next, cmd
+	}
+
+	b.model = next
+	return b, cmd
+}
+
+func (b Breadcrumb) View() string {
+	return Render(b.Path(), b.separator) + "\n" + b.model.View()
+}
@@ -0,0 +1,28 @@
+package breadcrumb
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	poly "github.com/trippwill/polymer"
+)
+
+// plain is a model that is not [poly.Atomic].
+type plain struct{}
+
+func (plain) Init() tea.Cmd                         { return nil }
+func (p plain) Update(tea.Msg) (tea.Model, tea.Cmd) { return p, nil }
+func (plain) View() string                          { return "plain" }
+
+func TestGetCurrentKeepsId(t *testing.T) {
+	b := New(plain{})
+	next, _ := b.Update("tick")
+
+	first, second := next.(poly.Modal).GetCurrent(), next.(poly.Modal).GetCurrent()
+	if first.Id() != second.Id() || first.Id() != b.GetCurrent().Id() {
+		t.Errorf("GetCurrent ids = %d, %d, %d; want one id", first.Id(), second.Id(), b.GetCurrent().Id())
+	}
+	if !poly.Contains(next, first.Id()) {
+		t.Errorf("breadcrumb does not contain its current model %d", first.Id())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"github.com/charmbracelet/bubbles/filepicker"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	poly "github.com/trippwill/polymer"
 )
@@ -28,6 +29,9 @@ type Config struct {
 }
 
 // Selector is a file/directory selector.
+//
+// It ends with a [Selection] of one path when a file is chosen, or is
+// cancelled with esc. See [poly.Done].
 type Selector struct {
 	poly.Atom
 	filepicker filepicker.Model
@@ -87,12 +91,18 @@ func (s Selector) Init() tea.Cmd {
 	)
 }
 
+var _ poly.KeyMapProvider = Selector{}
+
+// KeyMap implements [poly.KeyMapProvider].
+func (s Selector) KeyMap() []key.Binding {
+	return append(pickerKeys(s.filepicker.KeyMap), cancelKey.Binding())
+}
+
 func (s Selector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "esc":
-			return nil, nil
+		if cancelKey.Matches(msg) {
+			return poly.Cancel[Selection](s), nil
 		}
 	case tea.WindowSizeMsg:
 		s.filepicker.SetHeight(msg.Height - 2) // Leave space for title
@@ -113,7 +123,7 @@ func (s Selector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			selectionType = SelectionTypeFile
 		}
 
-		return nil, FileSelection([]string{path}, selectionType)
+		return poly.Done(s, Selection{Files: []string{path}, Type: selectionType}), nil
 	}
 
 	return s, cmd
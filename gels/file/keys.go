@@ -0,0 +1,36 @@
+package file
+
+import (
+	"github.com/charmbracelet/bubbles/filepicker"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/trippwill/polymer/keymap"
+)
+
+// Key bindings of the selectors, in the "file" scope of [keymap.Default].
+var (
+	cancelKey = keymap.Define("file", "cancel", key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "cancel"),
+	))
+	selectKey = keymap.Define("file", "select", key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "select"),
+	))
+	removeKey = keymap.Define("file", "remove", key.NewBinding(
+		key.WithKeys("delete"),
+		key.WithHelp("del", "remove"),
+	))
+	toggleViewKey = keymap.Define("file", "toggle-view", key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "toggle view"),
+	))
+	confirmKey = keymap.Define("file", "confirm", key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "confirm selection"),
+	))
+)
+
+// pickerKeys returns the bindings of a filepicker shown in help.
+func pickerKeys(keys filepicker.KeyMap) []key.Binding {
+	return []key.Binding{keys.Up, keys.Down, keys.Back, keys.Open, keys.Select}
+}
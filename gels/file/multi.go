@@ -24,7 +24,10 @@ func (s SelectedFileItem) FilterValue() string { return s.Name }
 
 var _ list.DefaultItem = SelectedFileItem{}
 
-// MultiSelector combines filepicker and list for multi-selection
+// MultiSelector combines filepicker and list for multi-selection.
+//
+// It ends with a [Selection] of the chosen paths when esc is pressed with
+// something selected. See [poly.Done].
 type MultiSelector struct {
 	poly.Atom
 	filepicker       filepicker.Model
@@ -69,22 +72,10 @@ func NewMultiSelector(config Config) *MultiSelector {
 	selectedList.Title = "Selected Files"
 	selectedList.AdditionalShortHelpKeys = func() []key.Binding {
 		return []key.Binding{
-			key.NewBinding(
-				key.WithKeys("delete"),
-				key.WithHelp("del", "remove"),
-			),
-			key.NewBinding(
-				key.WithKeys("tab"),
-				key.WithHelp("tab", "toggle view"),
-			),
-			key.NewBinding(
-				key.WithKeys("enter"),
-				key.WithHelp("enter", "confirm selection"),
-			),
-			key.NewBinding(
-				key.WithKeys("esc"),
-				key.WithHelp("esc", "cancel"),
-			),
+			removeKey.Binding(),
+			toggleViewKey.Binding(),
+			confirmKey.Binding(),
+			cancelKey.Binding(),
 		}
 	}
 
@@ -120,6 +111,14 @@ func (ms *MultiSelector) removeSelection(path string) {
 	ms.updateSelectedList()
 }
 
+// selection returns the current selection as a [Selection].
+func (ms MultiSelector) selection() Selection {
+	return Selection{
+		Files: ms.getSelectedPaths(),
+		Type:  ms.getSelectionType(),
+	}
+}
+
 func (ms MultiSelector) getSelectedPaths() []string {
 	var paths []string
 	for path := range ms.selected {
@@ -187,6 +186,28 @@ func (ms MultiSelector) Init() tea.Cmd {
 	)
 }
 
+var _ poly.KeyMapProvider = MultiSelector{}
+
+// KeyMap implements [poly.KeyMapProvider].
+func (ms MultiSelector) KeyMap() []key.Binding {
+	if ms.showingSelection {
+		return []key.Binding{
+			ms.selectedList.KeyMap.CursorUp,
+			ms.selectedList.KeyMap.CursorDown,
+			removeKey.Binding(),
+			toggleViewKey.Binding(),
+			confirmKey.Binding(),
+			cancelKey.Binding(),
+		}
+	}
+
+	return append(pickerKeys(ms.filepicker.KeyMap),
+		selectKey.Binding(),
+		toggleViewKey.Binding(),
+		cancelKey.Binding(),
+	)
+}
+
 func (ms MultiSelector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
@@ -194,35 +215,31 @@ func (ms MultiSelector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		ms.selectedList.SetSize(msg.Width, msg.Height)
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "tab":
+		switch {
+		case toggleViewKey.Matches(msg):
 			// Toggle between filepicker and selection views
 			ms.showingSelection = !ms.showingSelection
 			return ms, nil
 
-		case "esc":
+		case cancelKey.Matches(msg):
 			if ms.showingSelection {
 				ms.showingSelection = false
 				return ms, nil
 			}
-			// Complete selection or go back
+			// Complete selection
 			if len(ms.selected) > 0 {
-				paths := ms.getSelectedPaths()
-				selectionType := ms.getSelectionType()
-				return nil, FileSelection(paths, selectionType)
+				return poly.Done(ms, ms.selection()), nil
 			}
 			return ms, nil
 
-		case "enter":
+		case confirmKey.Matches(msg):
 			if ms.showingSelection && len(ms.selected) > 0 {
 				// Confirm selection from selection view
-				paths := ms.getSelectedPaths()
-				selectionType := ms.getSelectionType()
-				return nil, FileSelection(paths, selectionType)
+				return poly.Done(ms, ms.selection()), nil
 			}
 			// Handle in filepicker view below
 
-		case "delete":
+		case removeKey.Matches(msg):
 			if ms.showingSelection {
 				// Remove selected item from selection list
 				if selected, ok := ms.selectedList.SelectedItem().(SelectedFileItem); ok {
@@ -231,7 +248,7 @@ func (ms MultiSelector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return ms, nil
 			}
 
-		case "space":
+		case selectKey.Matches(msg):
 			if !ms.showingSelection {
 				// Add current file to selection if in filepicker view
 				if path := ms.filepicker.Path; path != "" {
@@ -1,6 +1,8 @@
 package file
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
 
 // SelectionType represents the type of file selection
 //
@@ -15,13 +17,23 @@ const (
 	SelectionTypeMixed
 )
 
-// FileSelectionMsg represents a file selection result
-type FileSelectionMsg struct {
+// Selection is the result of the file selectors.
+// Receive it with [poly.OpenResult]:
+//
+//	if result, ok := poly.OpenResult[file.Selection](msg); ok && !result.Cancelled { ... }
+type Selection struct {
 	Files []string
 	Type  SelectionType
 }
 
-// FileSelection creates a command to send file selection results
+// FileSelectionMsg represents a file selection result.
+//
+// Deprecated: the selectors end with a [Selection] result instead.
+type FileSelectionMsg = Selection
+
+// FileSelection creates a command to send file selection results.
+//
+// Deprecated: end the selector with [poly.Done] and a [Selection] instead.
 func FileSelection(files []string, selectionType SelectionType) tea.Cmd {
 	return func() tea.Msg {
 		return FileSelectionMsg{
@@ -0,0 +1,198 @@
+// Package help provides a searchable overview of the key bindings of an app.
+package help
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	poly "github.com/trippwill/polymer"
+)
+
+var (
+	frameStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+	titleStyle = lipgloss.NewStyle().Bold(true)
+	ownerStyle = lipgloss.NewStyle().Bold(true).Underline(true)
+	keyStyle   = lipgloss.NewStyle().Bold(true)
+	descStyle  = lipgloss.NewStyle().Faint(true)
+)
+
+// Help shows key bindings grouped by the model they belong to, filtered by
+// a search query typed by the user.
+//
+// It fills the window and returns nil when closed with esc, so it is
+// usually shown by the Host:
+//
+//	poly.NewHostWith("app", root, poly.WithHelp(help.New))
+type Help struct {
+	poly.Atom
+	groups []poly.KeyGroup
+	search textinput.Model
+	offset int // first body line shown
+	width  int
+	height int
+}
+
+// New creates a Help showing groups, outermost first. See [poly.KeyGroups].
+func New(groups []poly.KeyGroup) *Help {
+	search := textinput.New()
+	search.Prompt = "/ "
+	search.Placeholder = "search"
+	search.Focus()
+
+	return &Help{
+		Atom:   poly.NewAtom("Help"),
+		groups: groups,
+		search: search,
+	}
+}
+
+var _ poly.KeyMapProvider = Help{}
+
+// KeyMap implements [poly.KeyMapProvider].
+func (h Help) KeyMap() []key.Binding {
+	return []key.Binding{
+		closeKey.Binding(),
+		upKey.Binding(),
+		downKey.Binding(),
+		pageUpKey.Binding(),
+		pageDownKey.Binding(),
+	}
+}
+
+var _ poly.TextInput = Help{}
+
+// AcceptsText implements [poly.TextInput]. Typed keys go to the search box.
+func (h Help) AcceptsText() bool { return true }
+
+func (h Help) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (h Help) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		h.width, h.height = msg.Width, msg.Height
+		h.search.Width = max(0, h.width-8)
+		h.offset = h.clamp(h.offset)
+		return h, nil
+
+	case tea.KeyMsg:
+		switch {
+		case closeKey.Matches(msg):
+			if h.search.Value() == "" {
+				return nil, nil
+			}
+			h.search.SetValue("")
+			h.offset = 0
+			return h, nil
+		case upKey.Matches(msg):
+			h.offset = h.clamp(h.offset - 1)
+			return h, nil
+		case downKey.Matches(msg):
+			h.offset = h.clamp(h.offset + 1)
+			return h, nil
+		case pageUpKey.Matches(msg):
+			h.offset = h.clamp(h.offset - h.pageSize())
+			return h, nil
+		case pageDownKey.Matches(msg):
+			h.offset = h.clamp(h.offset + h.pageSize())
+			return h, nil
+		}
+	}
+
+	query := h.search.Value()
+	var cmd tea.Cmd
+	h.search, cmd = h.search.Update(msg)
+	if h.search.Value() != query {
+		h.offset = 0
+	}
+
+	return h, cmd
+}
+
+func (h Help) View() string {
+	header := titleStyle.Render("Help") + "\n" + h.search.View() + "\n"
+	body := h.body()
+	if len(body) == 0 {
+		body = []string{descStyle.Render("No matching keys")}
+	}
+
+	end := min(len(body), h.offset+h.pageSize())
+	content := header + "\n" + strings.Join(body[h.offset:end], "\n")
+
+	style := frameStyle
+	if h.width > 0 && h.height > 0 {
+		style = style.
+			Width(max(0, h.width-style.GetHorizontalBorderSize())).
+			Height(max(0, h.height-style.GetVerticalBorderSize()))
+	}
+
+	return style.Render(content)
+}
+
+// body returns the lines of the groups and bindings matching the search query.
+func (h Help) body() []string {
+	query := strings.ToLower(strings.TrimSpace(h.search.Value()))
+
+	var lines []string
+	for _, group := range h.groups {
+		bindings := group.Bindings
+		if query != "" && !strings.Contains(strings.ToLower(group.Owner), query) {
+			bindings = matching(bindings, query)
+		}
+		if len(bindings) == 0 {
+			continue
+		}
+
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, ownerStyle.Render(group.Owner))
+
+		width := 0
+		for _, binding := range bindings {
+			width = max(width, lipgloss.Width(binding.Help().Key))
+		}
+
+		for _, binding := range bindings {
+			help := binding.Help()
+			lines = append(lines, "  "+
+				keyStyle.Width(width).Render(help.Key)+"  "+
+				descStyle.Render(help.Desc))
+		}
+	}
+
+	return lines
+}
+
+// matching returns the bindings whose key or description contains query.
+func matching(bindings []key.Binding, query string) []key.Binding {
+	var matches []key.Binding
+	for _, binding := range bindings {
+		help := binding.Help()
+		if strings.Contains(strings.ToLower(help.Key), query) ||
+			strings.Contains(strings.ToLower(help.Desc), query) {
+			matches = append(matches, binding)
+		}
+	}
+
+	return matches
+}
+
+// pageSize returns the number of body lines that fit in the window.
+func (h Help) pageSize() int {
+	if h.height <= 0 {
+		return len(h.body())
+	}
+
+	_, frameHeight := frameStyle.GetFrameSize()
+	return max(1, h.height-frameHeight-3) // Leave space for the title and search
+}
+
+// clamp limits offset to the scrollable range of the body.
+func (h Help) clamp(offset int) int {
+	return max(0, min(offset, len(h.body())-h.pageSize()))
+}
@@ -0,0 +1,31 @@
+package help
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/trippwill/polymer/keymap"
+)
+
+// Key bindings of Help, in the "help" scope of [keymap.Default].
+// Other keys edit the search query.
+var (
+	closeKey = keymap.Define("help", "close", key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "clear search / close"),
+	))
+	upKey = keymap.Define("help", "up", key.NewBinding(
+		key.WithKeys("up"),
+		key.WithHelp("↑", "scroll up"),
+	))
+	downKey = keymap.Define("help", "down", key.NewBinding(
+		key.WithKeys("down"),
+		key.WithHelp("↓", "scroll down"),
+	))
+	pageUpKey = keymap.Define("help", "page-up", key.NewBinding(
+		key.WithKeys("pgup"),
+		key.WithHelp("pgup", "page up"),
+	))
+	pageDownKey = keymap.Define("help", "page-down", key.NewBinding(
+		key.WithKeys("pgdown"),
+		key.WithHelp("pgdown", "page down"),
+	))
+)
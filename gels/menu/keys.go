@@ -0,0 +1,26 @@
+package menu
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/trippwill/polymer/keymap"
+)
+
+// Key bindings of the Menu, in the "menu" scope of [keymap.Default].
+var (
+	selectKey = keymap.Define("menu", "select", key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "select"),
+	))
+	backKey = keymap.Define("menu", "back", key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "go back"),
+	))
+	historyBackKey = keymap.Define("menu", "history-back", key.NewBinding(
+		key.WithKeys("alt+left"),
+		key.WithHelp("alt+←", "history back"),
+	))
+	historyForwardKey = keymap.Define("menu", "history-forward", key.NewBinding(
+		key.WithKeys("alt+right"),
+		key.WithHelp("alt+→", "history forward"),
+	))
+)
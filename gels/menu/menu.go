@@ -2,6 +2,9 @@
 package menu
 
 import (
+	"encoding/json"
+	"slices"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -28,10 +31,19 @@ func (m Item) Description() string { return m.description }
 func (m Item) FilterValue() string { return m.Name() + " " + m.description }
 
 // Menu displays a list of options and activates the selected Atom.
+//
+// The selected Atom is mounted when it is activated and unmounted when it
+// exits or another Atom is activated. See [poly.Mount] and [poly.Unmount].
+// The [poly.ResultMsg] of an Atom that ends with [poly.Done] or [poly.Cancel]
+// is bubbled to the containers above the Menu.
+//
+// A nested Menu, or any other [poly.Navigator] on the active path, handles
+// back and forward navigation first while its own history can move.
 type Menu struct {
 	poly.Atom
 	list     list.Model
-	selected tea.Model
+	selected poly.Atomic
+	history  poly.History
 }
 
 // NewMenu creates a new Menu with the given title and items.
@@ -45,16 +57,11 @@ func NewMenu(title string, items ...Item) *Menu {
 	l.Title = title
 
 	l.AdditionalShortHelpKeys = func() []key.Binding {
-		return []key.Binding{
-			key.NewBinding(
-				key.WithKeys("enter"),
-				key.WithHelp("enter", "select"),
-			),
-			key.NewBinding(
-				key.WithKeys("esc"),
-				key.WithHelp("esc", "go back"),
-			),
-		}
+		return []key.Binding{selectKey.Binding(), backKey.Binding()}
+	}
+
+	l.AdditionalFullHelpKeys = func() []key.Binding {
+		return []key.Binding{historyBackKey.Binding(), historyForwardKey.Binding()}
 	}
 
 	return &Menu{
@@ -73,50 +80,226 @@ func (m *Menu) ConfigureList(fn func(*list.Model)) {
 var _ poly.Modal = Menu{}
 
 func (m Menu) GetCurrent() poly.Atomic {
-	switch selected := m.selected.(type) {
-	case nil:
+	if m.selected == nil {
 		return m
-	case poly.Atomic:
-		return selected
-	default:
-		return poly.NewAtomicTea(selected, "Selected")
 	}
+
+	return m.selected
+}
+
+var _ poly.Container = Menu{}
+
+// Children returns the selected model, if any.
+func (m Menu) Children() []tea.Model {
+	if m.selected == nil {
+		return nil
+	}
+
+	return []tea.Model{m.selected}
+}
+
+// snapshot is the snapshot of a [Menu].
+type snapshot struct {
+	Cursor   int             `json:"cursor"`          // Index of the highlighted item.
+	Selected int             `json:"selected"`        // Index of the selected item, or -1 for the list.
+	Child    json.RawMessage `json:"child,omitempty"` // Snapshot of the selected item.
+}
+
+var _ poly.Snapshotter = Menu{}
+
+var _ poly.KeyMapProvider = Menu{}
+
+// KeyMap implements [poly.KeyMapProvider]. While an item is active only
+// the history keys belong to the Menu.
+func (m Menu) KeyMap() []key.Binding {
+	if m.selected != nil {
+		return []key.Binding{historyBackKey.Binding(), historyForwardKey.Binding()}
+	}
+
+	bindings := []key.Binding{selectKey.Binding(), backKey.Binding()}
+	groups := m.list.FullHelp()
+	for _, group := range groups[:len(groups)-1] { // The last group quits the list.
+		bindings = append(bindings, group...)
+	}
+
+	return bindings
+}
+
+// Snapshot saves the highlighted item and the selected item with its state.
+// The selection history is not part of the snapshot.
+func (m Menu) Snapshot() ([]byte, error) {
+	s := snapshot{Cursor: m.list.Index(), Selected: -1}
+	if m.selected != nil {
+		s.Selected = m.indexOf(m.selected.Id())
+		data, err := poly.SnapshotModel(m.selected)
+		if err != nil {
+			return nil, err
+		}
+		s.Child = data
+	}
+
+	return json.Marshal(s)
+}
+
+// Restore highlights the saved item and activates the saved selection.
+func (m Menu) Restore(data []byte) (tea.Model, error) {
+	var s snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return m, err
+	}
+
+	items := m.list.Items()
+	if s.Cursor >= 0 && s.Cursor < len(items) {
+		m.list.Select(s.Cursor)
+	}
+
+	if s.Selected < 0 || s.Selected >= len(items) {
+		return m, nil
+	}
+
+	item, ok := items[s.Selected].(*Item)
+	if !ok {
+		return m, nil
+	}
+
+	selected, err := poly.RestoreModel(item.Atomic, s.Child)
+	if err != nil {
+		return m, err
+	}
+
+	m.selected = poly.AsAtomic(item.Atomic, selected)
+	return m, nil
+}
+
+// indexOf returns the index of the item with the given id, or -1.
+func (m Menu) indexOf(id uint32) int {
+	return slices.IndexFunc(m.list.Items(), func(item list.Item) bool {
+		i, ok := item.(*Item)
+		return ok && i.Id() == id
+	})
 }
 
 var _ poly.Atomic = Menu{}
 
-func (m Menu) Init() tea.Cmd { return tea.WindowSize() }
+func (m Menu) Init() tea.Cmd {
+	if m.selected != nil {
+		return tea.Batch(tea.WindowSize(), m.selected.Init())
+	}
+
+	return tea.WindowSize()
+}
+
+var _ poly.Navigator = Menu{}
+
+// History returns the selection history of the Menu.
+func (m Menu) History() poly.History { return m.history }
+
+var _ poly.TextInput = Menu{}
+
+// AcceptsText implements [poly.TextInput]. The list takes typed text while
+// it is being filtered.
+func (m Menu) AcceptsText() bool {
+	return m.selected == nil && m.list.FilterState() == list.Filtering
+}
 
 func (m Menu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.list.SetSize(msg.Width, msg.Height)
 
+	case poly.BackMsg:
+		if m.nested(poly.History.CanGoBack) {
+			break
+		}
+		if target, ok := m.history.Back(m.GetCurrent()); ok {
+			return m, m.activate(target)
+		}
+		return m, nil
+
+	case poly.ForwardMsg:
+		if m.nested(poly.History.CanGoForward) {
+			break
+		}
+		if target, ok := m.history.Forward(m.GetCurrent()); ok {
+			return m, m.activate(target)
+		}
+		return m, nil
+
 	case tea.KeyMsg:
+		switch {
+		case historyBackKey.Matches(msg) && !m.nested(poly.History.CanGoBack):
+			return m, poly.Back()
+		case historyForwardKey.Matches(msg) && !m.nested(poly.History.CanGoForward):
+			return m, poly.Forward()
+		}
+
 		if m.selected == nil {
-			switch msg.String() {
-			case "enter":
+			switch {
+			case selectKey.Matches(msg):
 				if selected, ok := m.list.SelectedItem().(*Item); ok && selected != nil {
-					m.selected = selected.Atomic
-					return m, m.selected.Init()
+					m.history.Visit(m.GetCurrent())
+					return m, m.activate(selected.Atomic)
 				}
 
-			case "esc":
+			case backKey.Matches(msg):
 				return nil, nil
 			}
 		}
 	}
 
-	var cmd tea.Cmd
 	if m.selected != nil {
-		m.selected, cmd = m.selected.Update(msg)
+		next, cmd := poly.Deliver(m.selected, msg)
+		if result, ok := poly.Exited(next); ok {
+			// The exited model cannot be activated again in its final state.
+			m.history.Forget(m.selected.Id())
+			cmd = tea.Batch(cmd, poly.Unmount(m.selected))
+			m.selected = nil
+			if result != nil {
+				cmd = tea.Batch(cmd, poly.Bubble(m, result))
+			}
+			return m, cmd
+		}
+		m.selected = poly.AsAtomic(m.selected, next)
 		return m, cmd
 	}
 
+	if poly.IsRouted(msg) {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
 	m.list, cmd = m.list.Update(msg)
 	return m, cmd
 }
 
+// nested reports whether a [poly.Navigator] below the Menu on the active
+// path can move through its history, so it handles the move instead.
+func (m Menu) nested(can func(poly.History) bool) bool {
+	if m.selected == nil {
+		return false
+	}
+
+	for _, atom := range poly.ActivePath(m.selected) {
+		if navigator, ok := atom.(poly.Navigator); ok && can(navigator.History()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// activate makes target the selected model, or shows the list if target is the Menu itself.
+func (m *Menu) activate(target poly.Atomic) tea.Cmd {
+	unmount := poly.Unmount(m.selected)
+	if target.Id() == m.Id() {
+		m.selected = nil
+		return unmount
+	}
+
+	m.selected = target
+	return tea.Batch(unmount, m.selected.Init(), poly.Mount(m.selected))
+}
+
 func (m Menu) View() string {
 	if m.selected != nil {
 		return m.selected.View()
@@ -0,0 +1,109 @@
+package menu
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	poly "github.com/trippwill/polymer"
+)
+
+// plain is a model that is not [poly.Atomic]. Wrapped with
+// [poly.NewAtomicTea] it becomes a child that turns into a plain model on
+// its first update.
+type plain struct{}
+
+func (plain) Init() tea.Cmd                         { return nil }
+func (p plain) Update(tea.Msg) (tea.Model, tea.Cmd) { return p, nil }
+func (plain) View() string                          { return "plain" }
+
+var enter = tea.KeyMsg{Type: tea.KeyEnter}
+
+func update(t *testing.T, model tea.Model, msg tea.Msg) Menu {
+	t.Helper()
+	next, _ := model.Update(msg)
+	menu, ok := next.(Menu)
+	if !ok {
+		t.Fatalf("Update(%T) returned %T, want Menu", msg, next)
+	}
+	return menu
+}
+
+func TestExitedChildIsNotRevisited(t *testing.T) {
+	a := poly.NewAtomicProxy("a") // exits on any message
+	m := update(t, NewMenu("menu", NewItem(a, "")), enter)
+	if m.GetCurrent().Id() != a.Id() {
+		t.Fatalf("selected %q, want a", m.GetCurrent().Name())
+	}
+
+	m = update(t, m, "tick")
+	if m.selected != nil {
+		t.Fatalf("exited child is still selected")
+	}
+
+	m = update(t, m, poly.BackMsg{})
+	if m.selected != nil {
+		t.Errorf("back re-activated the exited child %q", m.selected.Name())
+	}
+}
+
+func TestGetCurrentKeepsId(t *testing.T) {
+	a := poly.NewAtomicTea(plain{}, "a")
+	m := update(t, NewMenu("menu", NewItem(a, "")), enter)
+	m = update(t, m, "tick")
+	if first, second := m.GetCurrent(), m.GetCurrent(); first.Id() != a.Id() || second.Id() != a.Id() {
+		t.Errorf("GetCurrent ids = %d, %d; want %d", first.Id(), second.Id(), a.Id())
+	}
+}
+
+func TestNestedMenuHandlesBackFirst(t *testing.T) {
+	c := poly.NewAtomicTea(plain{}, "c")
+	inner := NewMenu("inner", NewItem(c, ""))
+	outer := update(t, NewMenu("outer", NewItem(inner, "")), enter)
+	outer = update(t, outer, enter)
+
+	path := poly.ActivePath(outer)
+	if leaf := path[len(path)-1]; leaf.Id() != c.Id() {
+		t.Fatalf("active leaf %q, want c", leaf.Name())
+	}
+
+	outer = update(t, outer, poly.BackMsg{})
+	if outer.GetCurrent().Id() != inner.Id() {
+		t.Fatalf("outer menu left the inner menu for %q", outer.GetCurrent().Name())
+	}
+	if got := outer.GetCurrent().(Menu); got.selected != nil {
+		t.Errorf("inner menu still shows %q", got.selected.Name())
+	}
+
+	outer = update(t, outer, poly.BackMsg{})
+	if outer.selected != nil {
+		t.Errorf("outer menu did not go back once the inner menu could not")
+	}
+}
+
+func TestFilterTakesTypedKeys(t *testing.T) {
+	opened := false
+	host := poly.NewHostWith("host", NewMenu("menu", NewItem(poly.NewAtomicTea(plain{}, "a"), "")),
+		poly.WithHelp(func([]poly.KeyGroup) tea.Model {
+			opened = true
+			return plain{}
+		}))
+
+	for _, msg := range []tea.Msg{
+		tea.WindowSizeMsg{Width: 40, Height: 20},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")},
+	} {
+		host, _ = host.Update(msg)
+	}
+
+	if opened {
+		t.Error("? opened help while filtering")
+	}
+	m := host.(poly.Container).Children()[0].(Menu)
+	if !m.AcceptsText() {
+		t.Error("menu is not filtering")
+	}
+	if got := m.list.FilterValue(); got != "?" {
+		t.Errorf("filter = %q, want ?", got)
+	}
+}
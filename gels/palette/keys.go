@@ -0,0 +1,27 @@
+package palette
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/trippwill/polymer/keymap"
+)
+
+// Key bindings of the Palette, in the "palette" scope of [keymap.Default].
+// Other keys edit the search query.
+var (
+	runKey = keymap.Define("palette", "run", key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "run"),
+	))
+	closeKey = keymap.Define("palette", "close", key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "close"),
+	))
+	upKey = keymap.Define("palette", "up", key.NewBinding(
+		key.WithKeys("up", "ctrl+k"),
+		key.WithHelp("↑", "previous"),
+	))
+	downKey = keymap.Define("palette", "down", key.NewBinding(
+		key.WithKeys("down", "ctrl+j"),
+		key.WithHelp("↓", "next"),
+	))
+)
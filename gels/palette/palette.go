@@ -0,0 +1,212 @@
+// Package palette provides a command palette that finds commands by fuzzy search.
+package palette
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sahilm/fuzzy"
+	poly "github.com/trippwill/polymer"
+)
+
+const (
+	visible  = 10 // number of commands shown at once
+	maxWidth = 60 // width of the palette inside its frame, at most
+)
+
+var (
+	frameStyle     = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+	cursorStyle    = lipgloss.NewStyle().Bold(true)
+	highlightStyle = lipgloss.NewStyle().Bold(true).Underline(true)
+	descStyle      = lipgloss.NewStyle().Faint(true)
+	keyStyle       = lipgloss.NewStyle().Faint(true)
+)
+
+// Palette lists commands, narrows them by fuzzy matching their names
+// against a query typed by the user, and runs the chosen one.
+//
+// It returns nil with the Run command of the chosen command on enter, and
+// nil alone on esc, so it is usually shown by the Host:
+//
+//	poly.NewHostWith("app", root, poly.WithPalette(palette.New))
+type Palette struct {
+	poly.Atom
+	commands []poly.Command
+	search   textinput.Model
+	matches  fuzzy.Matches
+	cursor   int
+	offset   int // first match shown
+	width    int
+}
+
+// New creates a Palette listing commands. See [poly.Commands].
+func New(commands []poly.Command) *Palette {
+	search := textinput.New()
+	search.Prompt = "> "
+	search.Placeholder = "Type a command"
+	search.Focus()
+
+	p := &Palette{
+		Atom:     poly.NewAtom("Command Palette"),
+		commands: commands,
+		search:   search,
+	}
+	p.filter()
+	return p
+}
+
+// source adapts commands to [fuzzy.Source], matching their names.
+type source []poly.Command
+
+func (s source) String(i int) string { return s[i].Name }
+func (s source) Len() int            { return len(s) }
+
+var _ poly.KeyMapProvider = Palette{}
+
+// KeyMap implements [poly.KeyMapProvider].
+func (p Palette) KeyMap() []key.Binding {
+	return []key.Binding{
+		runKey.Binding(),
+		closeKey.Binding(),
+		upKey.Binding(),
+		downKey.Binding(),
+	}
+}
+
+var _ poly.TextInput = Palette{}
+
+// AcceptsText implements [poly.TextInput]. Typed keys go to the search box.
+func (p Palette) AcceptsText() bool { return true }
+
+func (p Palette) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (p Palette) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width = msg.Width
+		p.search.Width = max(0, p.contentWidth()-lipgloss.Width(p.search.Prompt)-1)
+		return p, nil
+
+	case tea.KeyMsg:
+		switch {
+		case runKey.Matches(msg):
+			if len(p.matches) == 0 {
+				return p, nil
+			}
+			return nil, p.commands[p.matches[p.cursor].Index].Run
+		case closeKey.Matches(msg):
+			return nil, nil
+		case upKey.Matches(msg):
+			p.move(-1)
+			return p, nil
+		case downKey.Matches(msg):
+			p.move(1)
+			return p, nil
+		}
+	}
+
+	query := p.search.Value()
+	var cmd tea.Cmd
+	p.search, cmd = p.search.Update(msg)
+	if p.search.Value() != query {
+		p.filter()
+	}
+
+	return p, cmd
+}
+
+// filter matches the commands against the query and selects the best match.
+// With no query every command matches, in order.
+func (p *Palette) filter() {
+	p.cursor, p.offset = 0, 0
+	query := strings.TrimSpace(p.search.Value())
+	if query != "" {
+		p.matches = fuzzy.FindFrom(query, source(p.commands))
+		return
+	}
+
+	p.matches = make(fuzzy.Matches, len(p.commands))
+	for i, command := range p.commands {
+		p.matches[i] = fuzzy.Match{Str: command.Name, Index: i}
+	}
+}
+
+// move moves the cursor by delta matches, scrolling to keep it visible.
+func (p *Palette) move(delta int) {
+	if len(p.matches) == 0 {
+		return
+	}
+
+	p.cursor = max(0, min(p.cursor+delta, len(p.matches)-1))
+	switch {
+	case p.cursor < p.offset:
+		p.offset = p.cursor
+	case p.cursor >= p.offset+visible:
+		p.offset = p.cursor - visible + 1
+	}
+}
+
+func (p Palette) View() string {
+	width := p.contentWidth()
+	lines := []string{p.search.View(), ""}
+	if len(p.matches) == 0 {
+		lines = append(lines, descStyle.Render("No matching commands"))
+	}
+
+	end := min(len(p.matches), p.offset+visible)
+	for i, match := range p.matches[p.offset:end] {
+		lines = append(lines, p.viewMatch(match, p.offset+i == p.cursor, width))
+	}
+
+	return frameStyle.Width(width + frameStyle.GetHorizontalPadding()).Render(strings.Join(lines, "\n"))
+}
+
+// contentWidth returns the width of the palette inside its frame.
+func (p Palette) contentWidth() int {
+	if p.width <= 0 {
+		return maxWidth
+	}
+
+	return max(0, min(maxWidth, p.width-frameStyle.GetHorizontalFrameSize()))
+}
+
+// viewMatch renders a matched command on one line of width cells, with
+// the matched characters of its name highlighted and a cursor if selected.
+func (p Palette) viewMatch(match fuzzy.Match, selected bool, width int) string {
+	command := p.commands[match.Index]
+
+	var name strings.Builder
+	for i, r := range command.Name {
+		if slices.Contains(match.MatchedIndexes, i) {
+			name.WriteString(highlightStyle.Render(string(r)))
+		} else {
+			name.WriteRune(r)
+		}
+	}
+
+	left := name.String()
+	if command.Description != "" {
+		left += " " + descStyle.Render(command.Description)
+	}
+
+	right := ""
+	if command.Binding.Enabled() && command.Binding.Help().Key != "" {
+		right = keyStyle.Render(command.Binding.Help().Key)
+	}
+
+	cursor := "  "
+	if selected {
+		cursor = cursorStyle.Render("› ")
+	}
+
+	width -= lipgloss.Width(cursor)
+	gap := max(1, width-lipgloss.Width(left)-lipgloss.Width(right))
+	return cursor + ansi.Truncate(left+strings.Repeat(" ", gap)+right, width, "…")
+}
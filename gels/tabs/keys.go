@@ -0,0 +1,37 @@
+package tabs
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/trippwill/polymer/keymap"
+)
+
+// Key bindings of Tabs, in the "tabs" scope of [keymap.Default].
+var (
+	nextKey = keymap.Define("tabs", "next", key.NewBinding(
+		key.WithKeys("ctrl+pgdown"),
+		key.WithHelp("ctrl+pgdown", "next tab"),
+	))
+	previousKey = keymap.Define("tabs", "previous", key.NewBinding(
+		key.WithKeys("ctrl+pgup"),
+		key.WithHelp("ctrl+pgup", "previous tab"),
+	))
+)
+
+// tabKeys switch directly to the first nine tabs, alt+1 through alt+9 by
+// default, as the actions "tab-1" through "tab-9".
+var tabKeys = defineTabKeys()
+
+func defineTabKeys() []keymap.Action {
+	actions := make([]keymap.Action, 9)
+	for i := range actions {
+		n := strconv.Itoa(i + 1)
+		actions[i] = keymap.Define("tabs", "tab-"+n, key.NewBinding(
+			key.WithKeys("alt+"+n),
+			key.WithHelp("alt+"+n, "go to tab "+n),
+		))
+	}
+
+	return actions
+}
@@ -0,0 +1,301 @@
+// Package tabs provides a tab strip for switching between sibling Atoms.
+package tabs
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	poly "github.com/trippwill/polymer"
+)
+
+var (
+	activeTabStyle   = lipgloss.NewStyle().Bold(true).Underline(true).Padding(0, 1)
+	inactiveTabStyle = lipgloss.NewStyle().Faint(true).Padding(0, 1)
+	separator        = lipgloss.NewStyle().Faint(true).Render("│")
+)
+
+// Tabs displays a tab strip and the active tab beneath it.
+//
+// Key messages are delivered to the active tab only; other messages are
+// delivered to every initialized tab, so inactive tabs keep their state.
+// By default ctrl+pgdown and ctrl+pgup switch to the next and previous tab,
+// and alt+1 through alt+9 switch directly to a tab.
+//
+// Lazy tabs are mounted on their first activation, and removed tabs are
+// disposed. See [poly.Mount] and [poly.Dispose]. The [poly.ResultMsg] of a
+// tab that ends with [poly.Done] or [poly.Cancel] is bubbled to the
+// containers above the Tabs. When it is the last tab, the Tabs ends with
+// its result instead.
+type Tabs struct {
+	poly.Atom
+	tabs        []poly.Atomic
+	initialized []bool // whether each tab has been initialized, by position
+	active      int
+	width       int
+	height      int
+}
+
+// New creates a new Tabs with the given tabs, with the first tab active.
+func New(name string, tabs ...poly.Atomic) *Tabs {
+	if len(tabs) == 0 {
+		panic("tabs requires at least one tab")
+	}
+
+	initialized := make([]bool, len(tabs))
+	for i := range initialized {
+		initialized[i] = true
+	}
+
+	return &Tabs{
+		Atom:        poly.NewAtom(name),
+		tabs:        slices.Clone(tabs),
+		initialized: initialized,
+	}
+}
+
+// SetLazy controls whether each tab is initialized on its first activation
+// instead of when the Tabs is initialized.
+func (t *Tabs) SetLazy(lazy bool) {
+	initialized := make([]bool, len(t.tabs))
+	for i := range initialized {
+		initialized[i] = !lazy || i == t.active
+	}
+	t.initialized = initialized
+}
+
+// Active returns the index of the active tab.
+func (t Tabs) Active() int { return t.active }
+
+var _ poly.Modal = Tabs{}
+
+func (t Tabs) GetCurrent() poly.Atomic {
+	return t.tabs[t.active]
+}
+
+var _ poly.Container = Tabs{}
+
+// Children returns the initialized tabs.
+func (t Tabs) Children() []tea.Model {
+	children := make([]tea.Model, 0, len(t.tabs))
+	for i, tab := range t.tabs {
+		if t.initialized[i] {
+			children = append(children, tab)
+		}
+	}
+	return children
+}
+
+// snapshot is the snapshot of a [Tabs].
+type snapshot struct {
+	Active int               `json:"active"`
+	Tabs   []json.RawMessage `json:"tabs"` // Snapshots of the initialized tabs, by position.
+}
+
+var _ poly.Snapshotter = Tabs{}
+
+func (t Tabs) Snapshot() ([]byte, error) {
+	s := snapshot{Active: t.active, Tabs: make([]json.RawMessage, len(t.tabs))}
+	for i, tab := range t.tabs {
+		if !t.initialized[i] {
+			continue
+		}
+
+		data, err := poly.SnapshotModel(tab)
+		if err != nil {
+			return nil, err
+		}
+		s.Tabs[i] = data
+	}
+
+	return json.Marshal(s)
+}
+
+// Restore restores the active tab and the state of each tab.
+// Tabs are matched by position; extra snapshots are ignored.
+func (t Tabs) Restore(data []byte) (tea.Model, error) {
+	var s snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return t, err
+	}
+
+	tabs := slices.Clone(t.tabs)
+	for i, tab := range tabs {
+		if i >= len(s.Tabs) {
+			break
+		}
+
+		restored, err := poly.RestoreModel(tab, s.Tabs[i])
+		if err != nil {
+			return t, err
+		}
+		tabs[i] = poly.AsAtomic(tab, restored)
+	}
+
+	t.tabs = tabs
+	if s.Active >= 0 && s.Active < len(t.tabs) {
+		t.active = s.Active
+		t.initialized = slices.Clone(t.initialized)
+		t.initialized[t.active] = true
+	}
+
+	return t, nil
+}
+
+var _ poly.Atomic = Tabs{}
+
+func (t Tabs) Init() tea.Cmd {
+	var cmds []tea.Cmd
+	for i, tab := range t.tabs {
+		if t.initialized[i] {
+			cmds = append(cmds, tab.Init())
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+var _ poly.KeyMapProvider = Tabs{}
+
+// KeyMap implements [poly.KeyMapProvider].
+// The keys that switch directly to a tab are listed for existing tabs only.
+func (t Tabs) KeyMap() []key.Binding {
+	bindings := []key.Binding{nextKey.Binding(), previousKey.Binding()}
+	for _, action := range tabKeys[:min(len(tabKeys), len(t.tabs))] {
+		bindings = append(bindings, action.Binding())
+	}
+
+	return bindings
+}
+
+func (t Tabs) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		t.width, t.height = msg.Width, msg.Height
+		msg.Height = max(0, msg.Height-1) // Leave space for the tab strip
+		return t.broadcast(msg)
+
+	case tea.KeyMsg:
+		switch {
+		case nextKey.Matches(msg):
+			return t.activate((t.active + 1) % len(t.tabs))
+		case previousKey.Matches(msg):
+			return t.activate((t.active - 1 + len(t.tabs)) % len(t.tabs))
+		}
+
+		for i, action := range tabKeys {
+			if !action.Matches(msg) {
+				continue
+			}
+			if i < len(t.tabs) {
+				return t.activate(i)
+			}
+			return t, nil
+		}
+
+		return t.updateTab(t.active, msg)
+	}
+
+	return t.broadcast(msg)
+}
+
+// activate makes the tab at i active, initializing it on first activation.
+func (t Tabs) activate(i int) (tea.Model, tea.Cmd) {
+	t.active = i
+	tab := t.tabs[i]
+	if t.initialized[i] {
+		return t, nil
+	}
+
+	t.initialized = slices.Clone(t.initialized)
+	t.initialized[i] = true
+	cmd := tea.Batch(tab.Init(), poly.Mount(tab))
+	if t.width == 0 && t.height == 0 {
+		return t, cmd
+	}
+
+	next, sizeCmd := t.updateTab(i, tea.WindowSizeMsg{Width: t.width, Height: max(0, t.height-1)})
+	return next, tea.Batch(cmd, sizeCmd)
+}
+
+// broadcast delivers msg to every initialized tab.
+func (t Tabs) broadcast(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	for i := 0; i < len(t.tabs); {
+		if !t.initialized[i] {
+			i++
+			continue
+		}
+
+		routed, ok := poly.Route(msg, t.tabs[i])
+		if !ok {
+			i++
+			continue
+		}
+
+		count := len(t.tabs)
+		next, cmd := t.updateTab(i, routed)
+		cmds = append(cmds, cmd)
+		if _, ok := poly.Exited(next); ok {
+			return next, tea.Batch(cmds...)
+		}
+
+		t = next.(Tabs)
+		if len(t.tabs) == count {
+			i++
+		}
+	}
+
+	return t, tea.Batch(cmds...)
+}
+
+// updateTab delivers msg to the tab at i, removing it if it returns nil.
+func (t Tabs) updateTab(i int, msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := t.tabs[i].Update(msg)
+	result, exited := poly.Exited(next)
+	if !exited {
+		t.tabs = slices.Clone(t.tabs)
+		t.tabs[i] = poly.AsAtomic(t.tabs[i], next)
+		return t, cmd
+	}
+
+	if len(t.tabs) == 1 {
+		return next, cmd
+	}
+
+	removed := t.tabs[i]
+	t.tabs = slices.Delete(slices.Clone(t.tabs), i, i+1)
+	t.initialized = slices.Delete(slices.Clone(t.initialized), i, i+1)
+	cmd = tea.Batch(cmd, poly.Dispose(removed))
+	if result != nil {
+		cmd = tea.Batch(cmd, poly.Bubble(t, result))
+	}
+
+	removedActive := i == t.active
+	if i < t.active || t.active == len(t.tabs) {
+		t.active--
+	}
+
+	if removedActive {
+		next, activateCmd := t.activate(t.active)
+		return next, tea.Batch(cmd, activateCmd)
+	}
+
+	return t, cmd
+}
+
+func (t Tabs) View() string {
+	titles := make([]string, len(t.tabs))
+	for i, tab := range t.tabs {
+		if i == t.active {
+			titles[i] = activeTabStyle.Render(tab.Name())
+		} else {
+			titles[i] = inactiveTabStyle.Render(tab.Name())
+		}
+	}
+
+	strip := strings.Join(titles, separator)
+	return strip + "\n" + t.tabs[t.active].View()
+}
@@ -0,0 +1,99 @@
+package tabs
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	poly "github.com/trippwill/polymer"
+)
+
+// leaf counts its updates. It ends on a quitMsg and becomes another leaf
+// with a new id on a replaceMsg.
+type leaf struct {
+	poly.Atom
+	updates int
+}
+
+type (
+	quitMsg    struct{}
+	replaceMsg struct{}
+)
+
+func newLeaf(name string) leaf { return leaf{Atom: poly.NewAtom(name)} }
+
+func (l leaf) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg.(type) {
+	case quitMsg:
+		return poly.Done(l, l.Name()), nil
+	case replaceMsg:
+		return newLeaf(l.Name()), nil
+	}
+	l.updates++
+	return l, nil
+}
+
+func (l leaf) View() string { return l.Name() }
+
+func update(t *testing.T, model tea.Model, msg tea.Msg) Tabs {
+	t.Helper()
+	next, _ := model.Update(msg)
+	tabs, ok := next.(Tabs)
+	if !ok {
+		t.Fatalf("Update(%T) returned %T, want Tabs", msg, next)
+	}
+	return tabs
+}
+
+func TestReplacedTabStaysInitialized(t *testing.T) {
+	tabs := update(t, New("tabs", newLeaf("a"), newLeaf("b")), replaceMsg{})
+	if len(tabs.Children()) != 2 {
+		t.Fatalf("replaced tabs dropped out of the tree: %d children", len(tabs.Children()))
+	}
+
+	tabs = update(t, tabs, "tick")
+	for i, tab := range tabs.tabs {
+		if got := tab.(leaf).updates; got != 1 {
+			t.Errorf("tab %d received %d broadcasts, want 1", i, got)
+		}
+	}
+}
+
+func TestUpdateDoesNotAlias(t *testing.T) {
+	tabs := *New("tabs", newLeaf("a"), newLeaf("b"))
+	updated := update(t, tabs, "tick")
+	if got := updated.tabs[0].(leaf).updates; got != 1 {
+		t.Errorf("updated tabs: %d updates, want 1", got)
+	}
+	if got := tabs.tabs[0].(leaf).updates; got != 0 {
+		t.Errorf("original tabs: %d updates, want 0", got)
+	}
+
+	removed := update(t, tabs, poly.Envelope{Target: tabs.tabs[0].Id(), Msg: quitMsg{}})
+	if len(removed.tabs) != 1 || len(tabs.tabs) != 2 || tabs.tabs[0].Name() != "a" {
+		t.Errorf("removing a tab changed the original tabs: %v", tabs.Children())
+	}
+}
+
+func TestTabKeys(t *testing.T) {
+	tabs := *New("tabs", newLeaf("a"), newLeaf("b"))
+	tabs.SetLazy(true)
+
+	tabs = update(t, tabs, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2"), Alt: true})
+	if tabs.Active() != 1 || !tabs.initialized[1] {
+		t.Errorf("alt+2: active %d, initialized %v", tabs.Active(), tabs.initialized)
+	}
+
+	tabs = update(t, tabs, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3"), Alt: true})
+	if tabs.Active() != 1 {
+		t.Errorf("alt+3 with two tabs: active %d, want 1", tabs.Active())
+	}
+}
+
+func TestLastTabEndsWithResult(t *testing.T) {
+	a := newLeaf("a")
+	next, _ := New("tabs", a).Update(quitMsg{})
+	result, ok := poly.Exited(next)
+	if want := (poly.ResultMsg[string]{Source: a.Id(), Value: "a"}); !ok || result != want {
+		t.Errorf("Exited = %v, %v; want %v, true", result, ok, want)
+	}
+}
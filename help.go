@@ -0,0 +1,97 @@
+package polymer
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyMapProvider is implemented by models that describe their key bindings.
+// See [KeyGroups].
+type KeyMapProvider interface {
+	// KeyMap returns the bindings the model currently responds to.
+	KeyMap() []key.Binding
+}
+
+// KeyGroup holds the key bindings of one model, for help.
+type KeyGroup struct {
+	Owner    string        // Name of the model.
+	Bindings []key.Binding // Enabled bindings with help text.
+}
+
+// KeyGroups collects the key bindings of the models on the [ActivePath] of
+// root that implement [KeyMapProvider], outermost first. Models without
+// enabled bindings are left out.
+func KeyGroups(root tea.Model) []KeyGroup {
+	var groups []KeyGroup
+	for _, atom := range ActivePath(root) {
+		if group, ok := keyGroup(atom); ok {
+			groups = append(groups, group)
+		}
+	}
+
+	return groups
+}
+
+// keyGroup returns the key bindings of model, if it has any.
+func keyGroup(model tea.Model) (KeyGroup, bool) {
+	provider, ok := model.(KeyMapProvider)
+	if !ok {
+		return KeyGroup{}, false
+	}
+
+	group := KeyGroup{Owner: fmt.Sprintf("%T", model)}
+	if named, ok := model.(HasName); ok {
+		group.Owner = named.Name()
+	}
+
+	for _, binding := range provider.KeyMap() {
+		if binding.Enabled() && binding.Help().Key != "" {
+			group.Bindings = append(group.Bindings, binding)
+		}
+	}
+
+	return group, len(group.Bindings) > 0
+}
+
+// WithHelp opens the model returned by help, given the [KeyGroups] of the
+// Host and its active path, when the help key is pressed: ? by default,
+// unless it types text into a [TextInput]. The help model is shown over the
+// tree as a dialog until it returns nil.
+func WithHelp[M tea.Model](help func(groups []KeyGroup) M) HostOption {
+	return func(h *Host) {
+		h.help = func(groups []KeyGroup) tea.Model { return help(groups) }
+	}
+}
+
+var _ KeyMapProvider = Host{}
+
+// KeyMap implements [KeyMapProvider].
+func (h Host) KeyMap() []key.Binding {
+	bindings := []key.Binding{h.quitBinding()}
+	if h.help != nil {
+		bindings = append(bindings, showHelpKey.Binding())
+	}
+	if h.palette != nil {
+		bindings = append(bindings, paletteKey.Binding())
+	}
+
+	for _, action := range h.chords.actions {
+		bindings = append(bindings, action.Binding())
+	}
+
+	return bindings
+}
+
+// KeyGroups returns the key bindings of the Host followed by those of its
+// active path. See [KeyGroups].
+func (h Host) KeyGroups() []KeyGroup {
+	var groups []KeyGroup
+	if group, ok := keyGroup(h); ok {
+		group.Owner = h.name
+		groups = append(groups, group)
+	}
+
+	return append(groups, KeyGroups(h.state)...)
+}
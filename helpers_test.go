@@ -0,0 +1,96 @@
+package polymer
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// probe is a leaf model that records the messages it receives.
+//
+// It ends with [Done] on a doneMsg, returns nil on a quitMsg and returns
+// the model of a replaceMsg.
+type probe struct {
+	Atom
+	updates  int
+	received *[]tea.Msg
+}
+
+// doneMsg ends a probe with its value as the result.
+type doneMsg string
+
+// quitMsg ends a probe without a result.
+type quitMsg struct{}
+
+// replaceMsg replaces a probe with another model.
+type replaceMsg struct{ model tea.Model }
+
+func newProbe(name string) probe {
+	return probe{Atom: NewAtom(name), received: new([]tea.Msg)}
+}
+
+func (p probe) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	*p.received = append(*p.received, msg)
+	switch msg := msg.(type) {
+	case doneMsg:
+		return Done(p, string(msg)), nil
+	case quitMsg:
+		return nil, nil
+	case replaceMsg:
+		return msg.model, nil
+	}
+
+	p.updates++
+	return p, nil
+}
+
+func (p probe) View() string { return p.Name() }
+
+// got reports whether the probe received msg.
+func (p probe) got(msg tea.Msg) bool {
+	for _, m := range *p.received {
+		if m == msg {
+			return true
+		}
+	}
+	return false
+}
+
+// gotKey reports whether the probe received the key k.
+func (p probe) gotKey(k string) bool {
+	for _, m := range *p.received {
+		if key, ok := m.(tea.KeyMsg); ok && key.String() == k {
+			return true
+		}
+	}
+	return false
+}
+
+// collect runs cmd and every command it batches, returning their messages.
+func collect(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+
+	switch msg := cmd().(type) {
+	case nil:
+		return nil
+	case tea.BatchMsg:
+		var msgs []tea.Msg
+		for _, cmd := range msg {
+			msgs = append(msgs, collect(cmd)...)
+		}
+		return msgs
+	default:
+		return []tea.Msg{msg}
+	}
+}
+
+// envelopes returns the envelopes among msgs.
+func envelopes(msgs []tea.Msg) []Envelope {
+	var envs []Envelope
+	for _, msg := range msgs {
+		if env, ok := msg.(Envelope); ok {
+			envs = append(envs, env)
+		}
+	}
+	return envs
+}
@@ -0,0 +1,98 @@
+package polymer
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// History records visited [Atomic] models for back and forward navigation.
+//
+// The zero value is an empty History ready to use. Copies of a History may
+// be changed independently.
+type History struct {
+	back    []Atomic
+	forward []Atomic
+}
+
+// Visit records current as the location being left and clears the forward history.
+func (h *History) Visit(current Atomic) {
+	if current == nil {
+		return
+	}
+
+	h.back = append(slices.Clip(h.back), current)
+	h.forward = nil
+}
+
+// Back returns the previous location and records current in the forward history.
+// It returns false if there is no previous location.
+func (h *History) Back(current Atomic) (Atomic, bool) {
+	if len(h.back) == 0 {
+		return nil, false
+	}
+
+	target := h.back[len(h.back)-1]
+	h.back = h.back[:len(h.back)-1]
+	if current != nil {
+		h.forward = append(slices.Clip(h.forward), current)
+	}
+	return target, true
+}
+
+// Forward returns the next location and records current in the back history.
+// It returns false if there is no next location.
+func (h *History) Forward(current Atomic) (Atomic, bool) {
+	if len(h.forward) == 0 {
+		return nil, false
+	}
+
+	target := h.forward[len(h.forward)-1]
+	h.forward = h.forward[:len(h.forward)-1]
+	if current != nil {
+		h.back = append(slices.Clip(h.back), current)
+	}
+	return target, true
+}
+
+// Forget removes every record of the location with the given id,
+// e.g. because it has ended and must not be visited again.
+func (h *History) Forget(id uint32) {
+	recorded := func(atom Atomic) bool { return atom.Id() == id }
+	h.back = slices.DeleteFunc(slices.Clone(h.back), recorded)
+	h.forward = slices.DeleteFunc(slices.Clone(h.forward), recorded)
+}
+
+// CanGoBack reports whether there is a previous location.
+func (h History) CanGoBack() bool { return len(h.back) > 0 }
+
+// CanGoForward reports whether there is a next location.
+func (h History) CanGoForward() bool { return len(h.forward) > 0 }
+
+// Navigator is implemented by models that keep a [History]. Containers
+// with a History of their own let a Navigator below them handle [BackMsg]
+// and [ForwardMsg] first while it can move. The [Host] addresses these
+// messages to the outermost Navigator on its [ActivePath].
+type Navigator interface {
+	History() History
+}
+
+// BackMsg requests navigation to the previous location in a [History].
+type BackMsg struct{}
+
+// ForwardMsg requests navigation to the next location in a [History].
+type ForwardMsg struct{}
+
+// Back sends a [BackMsg].
+func Back() tea.Cmd {
+	return func() tea.Msg {
+		return BackMsg{}
+	}
+}
+
+// Forward sends a [ForwardMsg].
+func Forward() tea.Cmd {
+	return func() tea.Msg {
+		return ForwardMsg{}
+	}
+}
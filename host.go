@@ -1,27 +1,123 @@
 package polymer
 
 import (
+	"errors"
+
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/trippwill/polymer/keymap"
 	"github.com/trippwill/polymer/trace"
+	"github.com/trippwill/polymer/util"
 )
 
 type Host struct {
-	name  string
-	state tea.Model
+	name           string
+	state          tea.Model
+	lensOptions    []LensOption
+	router         *Router
+	start          *NavigateMsg
+	idErr          string         // last reported duplicate id error
+	quitKeys       *key.Binding   // keys set by WithQuitKeys, if any
+	alloc          util.Allocator // allocator set by WithAllocator, if any
+	confirmPrompt  string
+	confirming     bool // whether the quit confirmation is shown
+	altScreen      bool
+	mouse          MouseMode
+	width          int
+	height         int
+	pendingCode    int     // exit code of the quit awaiting confirmation
+	result         tea.Msg // ResultMsg the root ended with, if any
+	code           int     // exit code once the Host has quit
+	shutdown       []func() error
+	programOptions []tea.ProgramOption
+	snapshotFile   string
+	restoreErr     error // error restoring the snapshot file, reported by Init
+	snapshotErr    error // error saving the snapshot file, returned by Run
+	keymapFile     string
+	keymapErr      error // error loading or checking the keymap, reported by Init
+	chords         chords
+	help           func(groups []KeyGroup) tea.Model
+	commands       []Command
+	palette        func(commands []Command) tea.Model
+	dialog         tea.Model // help or command palette shown over the tree
+}
+
+// HostOption configures a Host.
+type HostOption func(*Host)
+
+// WithLens wraps the root of the Host in a [Lens] configured with options.
+func WithLens(options ...LensOption) HostOption {
+	return func(h *Host) {
+		h.lensOptions = append(h.lensOptions, options...)
+	}
+}
+
+// WithRouter enables [Navigate] using the routes registered with router.
+// The root of the Host is wrapped in a [Chain] if it is not one already,
+// and navigated routes are pushed onto it.
+func WithRouter(router *Router) HostOption {
+	return func(h *Host) {
+		h.router = router
+	}
 }
 
+// WithStartRoute navigates to the route derived from args when the Host is initialized.
+// It is typically used with os.Args[1:] to deep-link into a screen. See [RouteFromArgs].
+func WithStartRoute(args []string) HostOption {
+	return func(h *Host) {
+		path, params := RouteFromArgs(args)
+		if path == "" {
+			h.start = nil
+			return
+		}
+		h.start = &NavigateMsg{Path: path, Params: params}
+	}
+}
+
+// NewHost creates a Host for root. If options are given, root is wrapped
+// in a [Lens] configured with them. Use [NewHostWith] for other options.
 func NewHost(name string, root tea.Model, options ...LensOption) tea.Model {
+	return newHost(name, root, WithLens(options...))
+}
+
+// NewHostWith creates a Host for root configured with options.
+func NewHostWith(name string, root tea.Model, options ...HostOption) tea.Model {
+	return newHost(name, root, options...)
+}
+
+func newHost(name string, root tea.Model, options ...HostOption) *Host {
 	if root == nil {
 		panic("root state cannot be nil")
 	}
 
-	if len(options) > 0 {
-		root = NewLens(root, options...)
+	host := &Host{
+		name: name,
 	}
 
-	host := &Host{
-		name:  name,
-		state: root,
+	for _, opt := range options {
+		opt(host)
+	}
+
+	if host.start != nil && host.router == nil {
+		panic("start route requires a router")
+	}
+
+	if host.router != nil {
+		root = host.asChain(root)
+	}
+
+	if len(host.lensOptions) > 0 {
+		root = NewLens(root, host.lensOptions...)
+	}
+
+	host.state = root
+	if host.keymapFile != "" {
+		host.keymapErr = keymap.LoadFile(host.keymapFile)
+	}
+	host.keymapErr = errors.Join(host.keymapErr, keymap.Default.Check())
+
+	if host.snapshotFile != "" {
+		host.restoreErr = host.restoreSnapshot()
 	}
 
 	return host
@@ -31,30 +127,205 @@ var _ tea.Model = Host{}
 
 // Init implements [tea.Model].
 func (h Host) Init() tea.Cmd {
+	var start tea.Cmd
+	if h.start != nil {
+		start = Navigate(h.start.Path, h.start.Params)
+	}
+
+	var restoreErr, keymapErr tea.Cmd
+	if h.restoreErr != nil {
+		restoreErr = util.Broadcast(h.restoreErr)
+	}
+	if h.keymapErr != nil {
+		keymapErr = util.Broadcast(h.keymapErr)
+	}
+
+	var altScreen, mouse tea.Cmd
+	if h.altScreen {
+		altScreen = tea.EnterAltScreen
+	}
+	if h.mouse != MouseOff {
+		mouse = mouseCmd(h.mouse)
+	}
+
 	return tea.Sequence(
 		trace.TraceInfo(">>>> Initializing host: "+h.name),
 		tea.SetWindowTitle(h.name),
+		altScreen,
+		mouse,
 		h.state.Init(),
+		Mount(h.state),
+		restoreErr,
+		keymapErr,
+		start,
 		tea.WindowSize(),
 	)
 }
 
 // Update implements [tea.Model].
 func (h Host) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if h.state == nil {
+		return h, nil
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c":
-			return h, tea.Quit
+		if h.confirming {
+			return h.updateConfirm(msg)
+		}
+		typing := len(h.chords.pending) == 0 && h.typesText(msg)
+		if !typing && key.Matches(msg, h.quitBinding()) {
+			return h.requestQuit(ExitCancelled)
 		}
+		if h.dialog != nil {
+			return h.updateDialog(msg)
+		}
+		if h.help != nil && !typing && showHelpKey.Matches(msg) {
+			return h.openDialog(h.help(h.KeyGroups()))
+		}
+		if h.palette != nil && !typing && paletteKey.Matches(msg) {
+			return h.openDialog(h.palette(h.Commands()))
+		}
+		if len(h.chords.actions) > 0 && !typing {
+			return h.updateChord(msg)
+		}
+
+	case tea.MouseMsg:
+		if h.dialog != nil {
+			return h.updateDialog(msg)
+		}
+
+	case chordTimeoutMsg:
+		return h.chordTimeout(msg)
+
+	case tea.WindowSizeMsg:
+		h.width, h.height = msg.Width, msg.Height
+
+	case QuitMsg:
+		return h.requestQuit(msg.Code)
+
+	case AltScreenMsg:
+		h.altScreen = msg.Enabled
+		return h, altScreenCmd(msg.Enabled)
+
+	case MouseMsg:
+		h.mouse = msg.Mode
+		return h, mouseCmd(msg.Mode)
+
+	case PushMsg, ReplaceMsg, PopMsg, PopToRootMsg, BackMsg, ForwardMsg, ShowOverlayMsg, DismissOverlayMsg:
+		return h.deliver(h.address(msg))
+
+	case NavigateMsg:
+		if h.router == nil {
+			return h, trace.TraceWarn("navigation requested without a router: " + msg.Path)
+		}
+
+		atom, err := h.router.Resolve(msg.Path, msg.Params)
+		if err != nil {
+			return h, util.Broadcast(err)
+		}
+
+		return h, Push(atom)
 	}
 
-	var cmd tea.Cmd
-	h.state, cmd = h.state.Update(msg)
-	if h.state == nil {
-		return nil, tea.Quit
+	if h.dialog == nil || IsRouted(msg) {
+		return h.deliver(msg)
+	}
+
+	h, dialog := h.updateDialog(msg)
+	h, cmd := h.deliver(msg)
+	return h, tea.Batch(dialog, cmd)
+}
+
+// deliver delivers msg to the tree, quitting when the root exits.
+func (h Host) deliver(msg tea.Msg) (Host, tea.Cmd) {
+	next, cmd := Deliver(h.state, msg)
+	if result, ok := Exited(next); ok {
+		h.result, h.code = result, ExitOK
+		if isCancelled(result) {
+			h.code = ExitCancelled
+		}
+		h, quit := h.quit(true)
+		return h, tea.Batch(cmd, quit)
+	}
+
+	h.state = next
+	if _, ok := msg.(MountMsg); !ok {
+		return h, cmd
+	}
+
+	// Containers mount the models they add to the tree, so ids only need
+	// checking once a mount has been delivered.
+	checkIds := h.checkIds()
+	return h, tea.Batch(cmd, checkIds)
+}
+
+// address wraps a navigation message in an [Envelope] for the outermost
+// model on the active path that handles it, so sibling containers do not
+// all act on it. Other messages are returned unchanged.
+func (h Host) address(msg tea.Msg) tea.Msg {
+	for _, atom := range ActivePath(h.state) {
+		if handles(atom, msg) {
+			return Envelope{Target: atom.Id(), Msg: msg}
+		}
+	}
+
+	return msg
+}
+
+// handles reports whether atom handles the navigation message msg.
+func handles(atom Atomic, msg tea.Msg) bool {
+	switch msg.(type) {
+	case PushMsg, ReplaceMsg, PopMsg, PopToRootMsg:
+		return isChain(atom)
+	case BackMsg, ForwardMsg:
+		_, ok := atom.(Navigator)
+		return ok
+	case ShowOverlayMsg, DismissOverlayMsg:
+		switch atom.(type) {
+		case Overlay, *Overlay:
+			return true
+		}
+	}
+
+	return false
+}
+
+// typesText reports whether msg types text into a [TextInput] on the active
+// path, or on the dialog while one is shown.
+func (h Host) typesText(msg tea.KeyMsg) bool {
+	if msg.Alt || (msg.Type != tea.KeyRunes && msg.Type != tea.KeySpace) {
+		return false
 	}
-	return h, cmd
+
+	root := h.state
+	if h.dialog != nil {
+		root = h.dialog
+	}
+	for _, atom := range ActivePath(root) {
+		if input, ok := atom.(TextInput); ok && input.AcceptsText() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkIds reports duplicate ids in the tree through the error hooks,
+// once for each distinct set of duplicates. It walks the whole tree.
+func (h *Host) checkIds() tea.Cmd {
+	err := CheckIds(h.state)
+	if err == nil {
+		h.idErr = ""
+		return nil
+	}
+
+	if err.Error() == h.idErr {
+		return nil
+	}
+
+	h.idErr = err.Error()
+	return util.Broadcast(err)
 }
 
 // View implements [tea.Model].
@@ -63,5 +334,52 @@ func (h Host) View() string {
 		return ""
 	}
 
-	return h.state.View()
+	if h.confirming {
+		return h.viewConfirm(h.state.View())
+	}
+
+	return h.viewDialog(h.viewChord(h.state.View()))
+}
+
+// Children implements [Container].
+func (h Host) Children() []tea.Model { return []tea.Model{h.state} }
+
+// ActivePath returns the chain of active [Atomic] models below the Host.
+// See [ActivePath].
+func (h Host) ActivePath() []Atomic {
+	return ActivePath(h.state)
+}
+
+// asChain returns root as a [Chain], wrapping it if necessary.
+func (h Host) asChain(root tea.Model) tea.Model {
+	if isChain(root) {
+		return root
+	}
+
+	atom, ok := root.(Atomic)
+	if !ok {
+		atom = &teaAtomic{
+			Model:      root,
+			Named:      Named{name: h.name},
+			Identified: Identified{id: h.newAtom(h.name).Id()},
+		}
+	}
+
+	chain := NewChain(h.name, atom)
+	chain.Atom = h.newAtom(h.name)
+	return chain
+}
+
+// isChain reports whether model is a [Chain], or a [Lens] around one.
+func isChain(model tea.Model) bool {
+	switch model := model.(type) {
+	case Chain, *Chain:
+		return true
+	case Lens:
+		return isChain(model.Model)
+	case *Lens:
+		return isChain(model.Model)
+	default:
+		return false
+	}
 }
@@ -0,0 +1,94 @@
+package polymer
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/trippwill/polymer/keymap"
+	"github.com/trippwill/polymer/util"
+)
+
+func TestAsChainKeepsLensAroundChain(t *testing.T) {
+	h := Host{name: "host"}
+	chain := NewChain("chain", newProbe("root"))
+	lens := NewLens(chain)
+
+	if got := h.asChain(lens); got != lens {
+		t.Errorf("asChain wrapped the lens in %T", got)
+	}
+
+	wrapped, ok := h.asChain(NewLens(newProbe("root"))).(*Chain)
+	if !ok || wrapped.Depth() != 1 {
+		t.Errorf("asChain did not wrap a lens around a leaf in a chain")
+	}
+}
+
+func TestHostChecksIdsOnMount(t *testing.T) {
+	a, b := newProbe("a"), newProbe("b")
+	b.OverrideID(a.Id())
+	h := *newHost("host", NewFocusGroup("group", a, b))
+
+	h, _ = h.deliver("tick")
+	if h.idErr != "" {
+		t.Errorf("ids were checked for a plain message")
+	}
+
+	h, cmd := h.deliver(MountMsg{Model: h.state})
+	if h.idErr == "" || cmd == nil {
+		t.Errorf("duplicate ids were not reported after a mount")
+	}
+}
+
+func TestTextInputTakesHelpKey(t *testing.T) {
+	leaf := typist{newProbe("leaf")}
+	h := press(*newHost("host", leaf, WithHelp(func([]KeyGroup) tea.Model {
+		return newProbe("help")
+	})), runes("?"))
+
+	if !leaf.gotKey("?") {
+		t.Errorf("leaf received %v, want the key ?", *leaf.received)
+	}
+	if h.dialog != nil {
+		t.Errorf("help opened while typing: %v", h.dialog)
+	}
+
+	h = press(*newHost("host", newProbe("leaf"), WithHelp(func([]KeyGroup) tea.Model {
+		return newProbe("help")
+	})), runes("?"))
+	if h.dialog == nil {
+		t.Error("help did not open")
+	}
+}
+
+func TestTextInputTakesPaletteKey(t *testing.T) {
+	keymap.Override(keymap.Global, "palette", "p")
+	t.Cleanup(keymap.Default.Reset)
+
+	leaf := typist{newProbe("leaf")}
+	h := press(*newHost("host", leaf, WithPalette(func([]Command) tea.Model {
+		return newProbe("palette")
+	})), runes("p"))
+
+	if !leaf.gotKey("p") {
+		t.Errorf("leaf received %v, want the key p", *leaf.received)
+	}
+	if h.dialog != nil {
+		t.Errorf("palette opened while typing: %v", h.dialog)
+	}
+}
+
+func TestWithAllocator(t *testing.T) {
+	router := NewRouter()
+	a := *newHost("a", newProbe("root"), WithRouter(router), WithAllocator(util.NewNamespace(7)))
+	b := *newHost("b", newProbe("root"), WithRouter(router), WithAllocator(util.NewNamespace(8)))
+
+	if ns := a.state.(*Chain).Id() >> 24; ns != 7 {
+		t.Errorf("chain of host a allocated from namespace %d, want 7", ns)
+	}
+	if ns := b.state.(*Chain).Id() >> 24; ns != 8 {
+		t.Errorf("chain of host b allocated from namespace %d, want 8", ns)
+	}
+	if ns := util.NewId() >> 24; ns != 0 {
+		t.Errorf("creating hosts changed the default allocator: namespace %d", ns)
+	}
+}
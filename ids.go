@@ -0,0 +1,70 @@
+package polymer
+
+import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/trippwill/polymer/util"
+)
+
+// DuplicateIdError reports two [Atomic] models in the same tree sharing an id.
+type DuplicateIdError struct {
+	Id     uint32
+	First  Atomic
+	Second Atomic
+}
+
+func (e DuplicateIdError) Error() string {
+	return fmt.Sprintf("duplicate atom id %d: %s and %s", e.Id, formatModel(e.First), formatModel(e.Second))
+}
+
+// WithAllocator gives the Host its own allocator, usually a [util.Namespace]
+// reserved for it. The models the Host creates take their ids from alloc,
+// such as the [Chain] it wraps the root in for [WithRouter].
+//
+// [Run] also makes alloc the allocator of [util.NewId] until it returns, so
+// models created while it runs, such as navigated routes, take ids from its
+// range. That swap is process-wide: other Hosts running at the same time
+// allocate from alloc too. A Host run with [tea.NewProgram] leaves
+// [util.NewId] unchanged.
+func WithAllocator(alloc util.Allocator) HostOption {
+	return func(h *Host) {
+		h.alloc = alloc
+	}
+}
+
+// newAtom creates an [Atom] for a model created by the Host.
+func (h Host) newAtom(name string) Atom {
+	if h.alloc == nil {
+		return NewAtom(name)
+	}
+
+	return NewAtomFrom(h.alloc, name)
+}
+
+// CheckIds returns a [DuplicateIdError] for each id shared by two [Atomic]
+// models in the tree of model, joined with [errors.Join]. See [Walk].
+//
+// A [Host] checks its tree each time a [MountMsg] is delivered, and reports
+// new duplicates through [util.Broadcast].
+func CheckIds(model tea.Model) error {
+	seen := make(map[uint32]Atomic)
+	var errs []error
+	Walk(model, func(model tea.Model, _ int) bool {
+		atom, ok := model.(Atomic)
+		if !ok {
+			return true
+		}
+
+		if first, ok := seen[atom.Id()]; ok {
+			errs = append(errs, DuplicateIdError{Id: atom.Id(), First: first, Second: atom})
+			return true
+		}
+
+		seen[atom.Id()] = atom
+		return true
+	})
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,342 @@
+// Package keymap provides a registry of named key bindings that users can
+// override from a keymap file.
+//
+// Each gel defines its actions once, with default bindings:
+//
+//	var selectKey = keymap.Define("menu", "select", key.NewBinding(
+//		key.WithKeys("enter"),
+//		key.WithHelp("enter", "select"),
+//	))
+//
+// and matches key messages against them rather than against fixed strings:
+//
+//	if selectKey.Matches(msg) { ... }
+//
+// A keymap file replaces the keys of any action, and the help text of the
+// binding follows the replacement.
+//
+// A key may also be a sequence of keys separated by spaces, such as "g g",
+// for actions the Host matches as chords.
+package keymap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Global is the scope of actions that are active everywhere, such as quitting.
+// Their keys conflict with the keys of actions in every other scope.
+const Global = "global"
+
+// Registry holds named actions and their key bindings.
+type Registry struct {
+	mu        sync.RWMutex
+	actions   []Action
+	defaults  map[Action]key.Binding
+	overrides map[Action][]string
+}
+
+// NewRegistry creates an empty [Registry].
+func NewRegistry() *Registry {
+	return &Registry{
+		defaults:  make(map[Action]key.Binding),
+		overrides: make(map[Action][]string),
+	}
+}
+
+// Default is the registry used by the package-level functions and the gels.
+var Default = NewRegistry()
+
+// Action is a named action in a scope, such as "select" in "menu".
+type Action struct {
+	Scope    string
+	Name     string
+	registry *Registry
+}
+
+// String returns the action as scope.name.
+func (a Action) String() string { return a.Scope + "." + a.Name }
+
+// Binding returns the current key binding of the action.
+func (a Action) Binding() key.Binding {
+	if a.registry == nil {
+		return key.Binding{}
+	}
+
+	return a.registry.Binding(a)
+}
+
+// Keys returns the current keys of the action.
+func (a Action) Keys() []string { return a.Binding().Keys() }
+
+// Matches reports whether msg is bound to the action.
+func (a Action) Matches(msg tea.KeyMsg) bool {
+	return key.Matches(msg, a.Binding())
+}
+
+// Define adds an action to the [Default] registry. See [Registry.Define].
+func Define(scope, name string, binding key.Binding) Action {
+	return Default.Define(scope, name, binding)
+}
+
+// Define adds an action with a default binding and returns it.
+// It panics if the action is already defined.
+func (r *Registry) Define(scope, name string, binding key.Binding) Action {
+	if scope == "" || name == "" {
+		panic("action scope and name cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	action := Action{Scope: scope, Name: name, registry: r}
+	if _, ok := r.defaults[action]; ok {
+		panic("action already defined: " + action.String())
+	}
+
+	r.actions = append(r.actions, action)
+	r.defaults[action] = binding
+	return action
+}
+
+// Lookup returns the action with the given scope and name, if defined.
+func (r *Registry) Lookup(scope, name string) (Action, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	action := Action{Scope: scope, Name: name, registry: r}
+	_, ok := r.defaults[action]
+	return action, ok
+}
+
+// Actions returns every defined action in the order they were defined.
+func (r *Registry) Actions() []Action {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.actions)
+}
+
+// Binding returns the current key binding of action: its default binding,
+// or the default with its keys and help key replaced by an override.
+func (r *Registry) Binding(action Action) key.Binding {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	binding := r.defaults[action]
+	keys, ok := r.overrides[action]
+	if !ok {
+		return binding
+	}
+
+	if len(keys) == 0 {
+		return key.NewBinding(key.WithDisabled())
+	}
+
+	return key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(helpKeys(keys), binding.Help().Desc),
+	)
+}
+
+// Override replaces the keys of the action with the given scope and name.
+// With no keys the action is disabled. Overrides may be set before the
+// action is defined.
+func (r *Registry) Override(scope, name string, keys ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.overrides[Action{Scope: scope, Name: name, registry: r}] = slices.Clone(keys)
+}
+
+// Reset removes every override.
+func (r *Registry) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clear(r.overrides)
+}
+
+// Override replaces the keys of an action in the [Default] registry.
+// See [Registry.Override].
+func Override(scope, name string, keys ...string) {
+	Default.Override(scope, name, keys...)
+}
+
+// helpKeys renders keys for help text.
+func helpKeys(keys []string) string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		if k == " " {
+			k = "space"
+		}
+		names[i] = k
+	}
+
+	return strings.Join(names, "/")
+}
+
+// Conflict is a key bound to more than one action that can be active at once.
+type Conflict struct {
+	Key     string
+	Actions []Action
+}
+
+func (c Conflict) Error() string {
+	names := make([]string, len(c.Actions))
+	for i, action := range c.Actions {
+		names[i] = action.String()
+	}
+
+	return fmt.Sprintf("key %q is bound to %s", c.Key, strings.Join(names, ", "))
+}
+
+// Conflicts returns the keys bound to more than one action in the same
+// scope, or to an action in [Global] and any other action.
+func (r *Registry) Conflicts() []Conflict {
+	actions := r.Actions()
+	var conflicts []Conflict
+	for _, scope := range r.scopes() {
+		bound := make(map[string][]Action)
+		var keys []string
+		for _, action := range actions {
+			if action.Scope != scope && action.Scope != Global {
+				continue
+			}
+
+			for _, k := range r.Binding(action).Keys() {
+				if _, ok := bound[k]; !ok {
+					keys = append(keys, k)
+				}
+				bound[k] = append(bound[k], action)
+			}
+		}
+
+		for _, k := range keys {
+			if len(bound[k]) > 1 {
+				conflicts = appendConflict(conflicts, Conflict{Key: k, Actions: bound[k]})
+			}
+		}
+	}
+
+	return conflicts
+}
+
+// ErrUnknownAction is reported for overrides of actions that are not defined.
+var ErrUnknownAction = errors.New("unknown action")
+
+// Check returns the conflicts of the registry, and an error wrapping
+// [ErrUnknownAction] for each override of an action that is not defined,
+// as an error, or nil.
+func (r *Registry) Check() error {
+	var errs []error
+	for _, conflict := range r.Conflicts() {
+		errs = append(errs, conflict)
+	}
+	for _, action := range r.unknown() {
+		errs = append(errs, fmt.Errorf("keymap: %w: %s", ErrUnknownAction, action))
+	}
+
+	return errors.Join(errs...)
+}
+
+// unknown returns the overridden actions that are not defined, in order.
+func (r *Registry) unknown() []Action {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var unknown []Action
+	for action := range r.overrides {
+		if _, ok := r.defaults[action]; !ok {
+			unknown = append(unknown, action)
+		}
+	}
+
+	slices.SortFunc(unknown, func(a, b Action) int { return strings.Compare(a.String(), b.String()) })
+	return unknown
+}
+
+// scopes returns the scopes of the defined actions, with [Global] first.
+func (r *Registry) scopes() []string {
+	scopes := []string{Global}
+	for _, action := range r.Actions() {
+		if !slices.Contains(scopes, action.Scope) {
+			scopes = append(scopes, action.Scope)
+		}
+	}
+
+	return scopes
+}
+
+// appendConflict adds conflict unless it was already found through another
+// scope, as conflicts between [Global] actions are.
+func appendConflict(conflicts []Conflict, conflict Conflict) []Conflict {
+	for _, c := range conflicts {
+		if c.Key == conflict.Key && slices.Equal(c.Actions, conflict.Actions) {
+			return conflicts
+		}
+	}
+
+	return append(conflicts, conflict)
+}
+
+// Load reads overrides from a JSON keymap, mapping scopes to actions to keys:
+//
+//	{
+//		"global": {"quit": ["ctrl+q"]},
+//		"menu": {"select": ["enter", "l"], "back": ["esc", "h"]}
+//	}
+//
+// Actions that are not defined are skipped, and reported in the returned
+// error as [ErrUnknownAction]; the other overrides still apply.
+func (r *Registry) Load(reader io.Reader) error {
+	var keymap map[string]map[string][]string
+	if err := json.NewDecoder(reader).Decode(&keymap); err != nil {
+		return fmt.Errorf("keymap: %w", err)
+	}
+
+	var errs []error
+	for _, scope := range slices.Sorted(maps.Keys(keymap)) {
+		actions := keymap[scope]
+		for _, name := range slices.Sorted(maps.Keys(actions)) {
+			if _, ok := r.Lookup(scope, name); !ok {
+				errs = append(errs, fmt.Errorf("keymap: %w: %s.%s", ErrUnknownAction, scope, name))
+				continue
+			}
+			r.Override(scope, name, actions[name]...)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// LoadFile reads overrides from the JSON keymap file at path.
+// A missing file is not an error. See [Registry.Load].
+func (r *Registry) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("keymap: %w", err)
+	}
+	defer f.Close()
+
+	return r.Load(f)
+}
+
+// LoadFile reads overrides into the [Default] registry. See [Registry.LoadFile].
+func LoadFile(path string) error {
+	return Default.LoadFile(path)
+}
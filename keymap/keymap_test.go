@@ -0,0 +1,57 @@
+package keymap
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+func newTestRegistry() (*Registry, Action) {
+	r := NewRegistry()
+	r.Define(Global, "quit", key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "quit")))
+	selectKey := r.Define("menu", "select", key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")))
+	return r, selectKey
+}
+
+func TestLoad(t *testing.T) {
+	r, selectKey := newTestRegistry()
+	err := r.Load(strings.NewReader(`{
+		"menu": {"select": ["l"], "selcet": ["x"]},
+		"manu": {"select": ["y"]}
+	}`))
+
+	if !errors.Is(err, ErrUnknownAction) {
+		t.Fatalf("Load error = %v, want ErrUnknownAction", err)
+	}
+	for _, name := range []string{"menu.selcet", "manu.select"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("Load error %q does not name %s", err, name)
+		}
+	}
+
+	if got := selectKey.Keys(); !slices.Equal(got, []string{"l"}) {
+		t.Errorf("select keys = %v, want [l]", got)
+	}
+	if err := r.Check(); err != nil {
+		t.Errorf("Check after Load = %v, want nil", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	r, _ := newTestRegistry()
+	r.Override("menu", "missing", "m")
+	r.Override("menu", "select", "ctrl+c")
+
+	err := r.Check()
+	if !errors.Is(err, ErrUnknownAction) || !strings.Contains(err.Error(), "menu.missing") {
+		t.Errorf("Check = %v, want the unknown action menu.missing", err)
+	}
+
+	var conflict Conflict
+	if !errors.As(err, &conflict) || conflict.Key != "ctrl+c" {
+		t.Errorf("Check = %v, want a conflict on ctrl+c", err)
+	}
+}
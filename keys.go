@@ -0,0 +1,78 @@
+package polymer
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/trippwill/polymer/keymap"
+)
+
+// Key bindings of the core models. Users override them by scope and name,
+// e.g. from a keymap file. See [WithKeymapFile].
+var (
+	quitKey = keymap.Define(keymap.Global, "quit", key.NewBinding(
+		key.WithKeys("ctrl+c"),
+		key.WithHelp("ctrl+c", "quit"),
+	))
+	showHelpKey = keymap.Define(keymap.Global, "help", key.NewBinding(
+		key.WithKeys("?"),
+		key.WithHelp("?", "help"),
+	))
+	paletteKey = keymap.Define(keymap.Global, "palette", key.NewBinding(
+		key.WithKeys("ctrl+p"),
+		key.WithHelp("ctrl+p", "command palette"),
+	))
+	confirmQuitKey = keymap.Define("host", "confirm-quit", key.NewBinding(
+		key.WithKeys("y", "Y", "enter"),
+		key.WithHelp("y", "quit"),
+	))
+	cancelQuitKey = keymap.Define("host", "cancel-quit", key.NewBinding(
+		key.WithKeys("n", "N", "esc"),
+		key.WithHelp("n", "cancel"),
+	))
+	retryKey = keymap.Define("boundary", "retry", key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "retry"),
+	))
+	dismissKey = keymap.Define("boundary", "dismiss", key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "dismiss"),
+	))
+	nextFocusKey = keymap.Define("focus", "next", key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "next"),
+	))
+	previousFocusKey = keymap.Define("focus", "previous", key.NewBinding(
+		key.WithKeys("shift+tab"),
+		key.WithHelp("shift+tab", "previous"),
+	))
+	growKey = keymap.Define("split", "grow", key.NewBinding(
+		key.WithKeys("ctrl+right"),
+		key.WithHelp("ctrl+→", "grow pane"),
+	))
+	shrinkKey = keymap.Define("split", "shrink", key.NewBinding(
+		key.WithKeys("ctrl+left"),
+		key.WithHelp("ctrl+←", "shrink pane"),
+	))
+	growVerticalKey = keymap.Define("split", "grow-vertical", key.NewBinding(
+		key.WithKeys("ctrl+down"),
+		key.WithHelp("ctrl+↓", "grow pane"),
+	))
+	shrinkVerticalKey = keymap.Define("split", "shrink-vertical", key.NewBinding(
+		key.WithKeys("ctrl+up"),
+		key.WithHelp("ctrl+↑", "shrink pane"),
+	))
+)
+
+// WithKeymapFile overrides key bindings from the JSON keymap file at path
+// when the Host is created. Errors reading the file, unknown actions and
+// conflicting bindings are reported through the error hooks. See
+// [keymap.Registry.Load].
+func WithKeymapFile(path string) HostOption {
+	return func(h *Host) {
+		h.keymapFile = path
+	}
+}
+
+// helpKey returns the key shown in help text for action.
+func helpKey(action keymap.Action) string {
+	return action.Binding().Help().Key
+}
@@ -10,7 +10,7 @@ type Modal interface {
 	GetCurrent() Atomic // GetCurrent returns the current active model.
 }
 
-// Lens provides lifecycle hooks for [tea.Model].
+// Lens provides lifecycle hooks and [Middleware] for [tea.Model].
 type Lens struct {
 	tea.Model
 	Atom
@@ -20,6 +20,11 @@ type Lens struct {
 	OnView       OnView       // Called when an Atom is rendered.
 	OnError      OnError      // Called when an error occurs in an Atom.
 	OnTrace      OnTrace      // Called when an Atom sends a trace message.
+	OnMount      OnMount      // Called when a model is mounted.
+	OnUnmount    OnUnmount    // Called when a model is unmounted.
+	OnDispose    OnDispose    // Called when a model is disposed.
+	boundary     *boundary    // Set by WithErrorBoundary.
+	middleware   []Middleware // Set by WithMiddleware.
 }
 
 // LensOption configures a Lens.
@@ -33,6 +38,9 @@ type (
 	OnView       func(active tea.Model, rendered string)
 	OnError      func(active tea.Model, err error)
 	OnTrace      func(active tea.Model, level trace.Level, msg string)
+	OnMount      func(mounted tea.Model)
+	OnUnmount    func(unmounted tea.Model)
+	OnDispose    func(disposed tea.Model)
 )
 
 // NewLens wraps a [tea.Model] in a Lens, allowing for lifecycle hooks to be added.
@@ -91,10 +99,51 @@ func WithOnTrace(fn OnTrace) LensOption {
 	}
 }
 
-var _ Atomic = Lens{}
+// WithOnMount sets the OnMount hook.
+func WithOnMount(fn OnMount) LensOption {
+	return func(h *Lens) {
+		h.OnMount = fn
+	}
+}
+
+// WithOnUnmount sets the OnUnmount hook.
+func WithOnUnmount(fn OnUnmount) LensOption {
+	return func(h *Lens) {
+		h.OnUnmount = fn
+	}
+}
+
+// WithOnDispose sets the OnDispose hook.
+func WithOnDispose(fn OnDispose) LensOption {
+	return func(h *Lens) {
+		h.OnDispose = fn
+	}
+}
+
+var (
+	_ Atomic      = Lens{}
+	_ Snapshotter = Lens{}
+)
+
+// Snapshot implements [Snapshotter].
+func (l Lens) Snapshot() ([]byte, error) { return SnapshotModel(l.Model) }
+
+// Restore implements [Snapshotter].
+func (l Lens) Restore(data []byte) (tea.Model, error) {
+	model, err := RestoreModel(l.Model, data)
+	if err != nil {
+		return l, err
+	}
+
+	l.Model = model
+	return l, nil
+}
+
+// Children implements [Container].
+func (l Lens) Children() []tea.Model { return []tea.Model{l.Model} }
 
 func (l Lens) Init() tea.Cmd {
-	cmd := l.Model.Init()
+	cmd := l.init()
 	if l.OnInit != nil {
 		l.OnInit(resolve(l.Model), cmd)
 	}
@@ -103,6 +152,27 @@ func (l Lens) Init() tea.Cmd {
 }
 
 func (l Lens) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Lifecycle messages are observed even when the model does not receive them.
+	switch msg := msg.(type) {
+	case MountMsg:
+		if l.OnMount != nil {
+			l.OnMount(msg.Model)
+		}
+	case UnmountMsg:
+		if l.OnUnmount != nil {
+			l.OnUnmount(msg.Model)
+		}
+	case DisposeMsg:
+		if l.OnDispose != nil {
+			l.OnDispose(msg.Model)
+		}
+	}
+
+	msg, ok := Route(msg, l.Model)
+	if !ok {
+		return l, nil
+	}
+
 	switch msg := msg.(type) {
 	case error:
 		if l.OnError != nil {
@@ -114,24 +184,61 @@ func (l Lens) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	if l.boundary.failed() {
+		return l.updateFailed(msg)
+	}
+
+	if len(l.middleware) == 0 {
+		return l.deliver(msg)
+	}
+
+	// The model may be updated any number of times by the middleware.
+	var exit tea.Model
+	exited := false
+	cmd := pipeline(l.middleware, func(msg tea.Msg) tea.Cmd {
+		if exited {
+			return nil
+		}
+
+		next, cmd := l.deliver(msg)
+		if next, ok := next.(Lens); ok {
+			l = next
+			return cmd
+		}
+
+		exit, exited = next, true
+		return cmd
+	})(msg)
+
+	if exited {
+		return exit, cmd
+	}
+
+	return l, cmd
+}
+
+// deliver updates the model with msg, calling the update hooks.
+// It returns the model itself if it exited.
+func (l Lens) deliver(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if l.BeforeUpdate != nil {
 		l.BeforeUpdate(resolve(l.Model), msg)
 	}
 
-	next, cmd := l.Model.Update(msg)
+	next, cmd := l.update(msg)
 	if l.AfterUpdate != nil {
 		l.AfterUpdate(resolve(next), cmd)
 	}
 
+	if _, ok := Exited(next); ok {
+		return next, cmd
+	}
+
 	l.Model = next
 	return l, cmd
 }
 
 func (l Lens) View() string {
-	rendered := ""
-	if l.Model != nil {
-		rendered = l.Model.View()
-	}
+	rendered := l.view()
 
 	if l.OnView != nil {
 		l.OnView(resolve(l.Model), rendered)
@@ -140,12 +247,13 @@ func (l Lens) View() string {
 	return rendered
 }
 
-// resolve recursively resolves a [tea.Model] through [Lens] and [Modal] types.
+// resolve recursively resolves a [tea.Model] through [Lens], [Modal] and
+// single-child [Container] types.
 func resolve(model tea.Model) tea.Model {
 	switch a := model.(type) {
 	case Modal:
 		current := a.GetCurrent()
-		if current.Id() != a.Id() {
+		if current != nil && current.Id() != a.Id() {
 			// If the current model is not the same as the modal's ID, resolve it.
 			return resolve(current)
 		}
@@ -154,7 +262,59 @@ func resolve(model tea.Model) tea.Model {
 		return resolve(a.Model)
 	case Lens:
 		return resolve(a.Model)
+	case Container:
+		if only, ok := onlyChild(a); ok {
+			return resolve(only)
+		}
+		return model
 	default:
 		return a
 	}
 }
+
+// onlyChild returns the child of container if it has exactly one.
+func onlyChild(container Container) (tea.Model, bool) {
+	children := container.Children()
+	if len(children) != 1 || children[0] == nil {
+		return nil, false
+	}
+
+	return children[0], true
+}
+
+// ActivePath returns the chain of active [Atomic] models from model down to the leaf,
+// following [Modal.GetCurrent] and single-child [Container] types.
+// [Lens] wrappers are transparent and are not included.
+func ActivePath(model tea.Model) []Atomic {
+	var path []Atomic
+	for model != nil {
+		switch a := model.(type) {
+		case *Lens:
+			model = a.Model
+		case Lens:
+			model = a.Model
+		case Modal:
+			path = append(path, a)
+			current := a.GetCurrent()
+			if current == nil || current.Id() == a.Id() {
+				return path
+			}
+			model = current
+		case Container:
+			if atom, ok := a.(Atomic); ok {
+				path = append(path, atom)
+			}
+			only, ok := onlyChild(a)
+			if !ok {
+				return path
+			}
+			model = only
+		case Atomic:
+			return append(path, a)
+		default:
+			return path
+		}
+	}
+
+	return path
+}
@@ -0,0 +1,148 @@
+package polymer
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Mounter is implemented by models that are notified when a container
+// adds them to the live tree, e.g. when a [Chain] pushes them or a menu
+// activates them.
+type Mounter interface {
+	OnMount() tea.Cmd
+}
+
+// Unmounter is implemented by models that are notified when a container
+// removes them from the live tree. An unmounted model may be mounted again.
+type Unmounter interface {
+	OnUnmount() tea.Cmd
+}
+
+// Disposer is implemented by models that hold resources to release when
+// they are discarded and will not be mounted again.
+type Disposer interface {
+	Dispose()
+}
+
+// MountMsg reports that a model was mounted.
+// It is delivered to containers only, so [Lens] hooks can observe it.
+type MountMsg struct {
+	Model tea.Model
+}
+
+// UnmountMsg reports that a model was unmounted.
+// It is delivered to containers only, so [Lens] hooks can observe it.
+type UnmountMsg struct {
+	Model tea.Model
+}
+
+// DisposeMsg reports that a model was disposed.
+// It is delivered to containers only, so [Lens] hooks can observe it.
+type DisposeMsg struct {
+	Model tea.Model
+}
+
+var (
+	_ Routed = MountMsg{}
+	_ Routed = UnmountMsg{}
+	_ Routed = DisposeMsg{}
+)
+
+// RouteTo implements [Routed].
+func (m MountMsg) RouteTo(child tea.Model) (tea.Msg, bool) { return m, isContainer(child) }
+
+// RouteTo implements [Routed].
+func (m UnmountMsg) RouteTo(child tea.Model) (tea.Msg, bool) { return m, isContainer(child) }
+
+// RouteTo implements [Routed].
+func (m DisposeMsg) RouteTo(child tea.Model) (tea.Msg, bool) { return m, isContainer(child) }
+
+// Mount calls OnMount on model and every [Mounter] below it, and sends a [MountMsg].
+// Containers call it when they add a child to the live tree.
+func Mount(model tea.Model) tea.Cmd {
+	if model == nil {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	Walk(model, func(m tea.Model, _ int) bool {
+		if mounter, ok := m.(Mounter); ok {
+			cmds = append(cmds, mounter.OnMount())
+		}
+		return true
+	})
+
+	return tea.Batch(append(cmds, func() tea.Msg { return MountMsg{Model: model} })...)
+}
+
+// Unmount calls OnUnmount on model and every [Unmounter] below it, deepest
+// first, cancels their lifetime contexts and sends an [UnmountMsg]. Containers
+// call it when they remove a child from the live tree that may be mounted
+// again. See [Context].
+func Unmount(model tea.Model) tea.Cmd {
+	if model == nil {
+		return nil
+	}
+
+	return tea.Batch(unmount(model), func() tea.Msg { return UnmountMsg{Model: model} })
+}
+
+// Dispose unmounts model, then calls Dispose on model and every [Disposer]
+// below it, deepest first, and sends a [DisposeMsg]. Containers call it when
+// they discard a child.
+//
+// A container that returns nil does not dispose its own children; they are
+// disposed with it by its parent.
+func Dispose(model tea.Model) tea.Cmd {
+	if model == nil {
+		return nil
+	}
+
+	return tea.Batch(dispose(model), func() tea.Msg { return DisposeMsg{Model: model} })
+}
+
+// dispose unmounts model, then calls Dispose on model and every [Disposer]
+// below it, deepest first.
+func dispose(model tea.Model) tea.Cmd {
+	cmd := unmount(model)
+	for _, m := range postOrder(model) {
+		if disposer, ok := m.(Disposer); ok {
+			disposer.Dispose()
+		}
+	}
+
+	return cmd
+}
+
+// unmount calls OnUnmount on model and every [Unmounter] below it, deepest
+// first, and cancels their lifetime contexts.
+func unmount(model tea.Model) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, m := range postOrder(model) {
+		if unmounter, ok := m.(Unmounter); ok {
+			cmds = append(cmds, unmounter.OnUnmount())
+		}
+		if atom, ok := m.(HasId); ok {
+			cancelScope(atom.Id())
+		}
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// postOrder returns the tree of model with every model after its descendants.
+func postOrder(model tea.Model) []tea.Model {
+	var models []tea.Model
+	Walk(model, func(m tea.Model, _ int) bool {
+		models = append(models, m)
+		return true
+	})
+
+	// Reversing a pre-order walk visits children right to left, but always
+	// before their parent.
+	ordered := make([]tea.Model, len(models))
+	for i, m := range models {
+		ordered[len(models)-1-i] = m
+	}
+
+	return ordered
+}
@@ -1,6 +1,7 @@
 package polymer
 
 import (
+	"errors"
 	"fmt"
 	"log"
 
@@ -36,12 +37,31 @@ func WithLifecycleLogging(logger *log.Logger) []LensOption {
 			logger.Print(formatLog(
 				"OnError",
 				fmt.Sprintf("for %s with error: %v", formatModel(active), err)))
+			var panicErr *PanicError
+			if errors.As(err, &panicErr) {
+				logger.Print(formatLog("OnError", string(panicErr.Stack)))
+			}
 		}),
 		WithOnTrace(func(active tea.Model, level trace.Level, msg string) {
 			logger.Print(formatLog(
 				"OnNotify",
 				fmt.Sprintf("[%s] for %s '%s'", level, formatModel(active), msg)))
 		}),
+		WithOnMount(func(mounted tea.Model) {
+			logger.Print(formatLog(
+				"OnMount",
+				fmt.Sprintf("for %s", formatModel(mounted))))
+		}),
+		WithOnUnmount(func(unmounted tea.Model) {
+			logger.Print(formatLog(
+				"OnUnmount",
+				fmt.Sprintf("for %s", formatModel(unmounted))))
+		}),
+		WithOnDispose(func(disposed tea.Model) {
+			logger.Print(formatLog(
+				"OnDispose",
+				fmt.Sprintf("for %s", formatModel(disposed))))
+		}),
 	}
 }
 
@@ -0,0 +1,129 @@
+package polymer
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Next passes a message on to the rest of a middleware pipeline and,
+// at its end, to the model wrapped by the [Lens].
+type Next func(msg tea.Msg) tea.Cmd
+
+// Middleware intercepts the messages delivered to the model wrapped by a [Lens].
+//
+// A middleware may call next with msg, with a different message, several
+// times or not at all, and may transform the command next returns. Messages
+// can be delayed by returning a command that produces them later.
+type Middleware func(msg tea.Msg, next Next) tea.Cmd
+
+// WithMiddleware adds middleware to the Lens. The first middleware added
+// receives messages first; hooks such as BeforeUpdate observe the messages
+// that reach the model.
+func WithMiddleware(middleware ...Middleware) LensOption {
+	return func(l *Lens) {
+		l.middleware = append(l.middleware, middleware...)
+	}
+}
+
+// Compose combines middleware into one, running them in order.
+func Compose(middleware ...Middleware) Middleware {
+	middleware = slices.Clone(middleware)
+	return func(msg tea.Msg, next Next) tea.Cmd {
+		return pipeline(middleware, next)(msg)
+	}
+}
+
+// pipeline returns the [Next] that runs msg through middleware and then last.
+func pipeline(middleware []Middleware, last Next) Next {
+	next := last
+	for i := len(middleware) - 1; i >= 0; i-- {
+		mw, rest := middleware[i], next
+		next = func(msg tea.Msg) tea.Cmd { return mw(msg, rest) }
+	}
+
+	return next
+}
+
+// RemapKeys replaces key messages by the keys they map to, e.g.
+//
+//	poly.RemapKeys(map[string]string{"j": "down", "k": "up"})
+//
+// Keys are written as [tea.KeyMsg.String] formats them.
+func RemapKeys(mapping map[string]string) Middleware {
+	keys := make(map[string]tea.KeyMsg, len(mapping))
+	for from, to := range mapping {
+		keys[from] = parseKey(to)
+	}
+
+	return func(msg tea.Msg, next Next) tea.Cmd {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			if to, ok := keys[key.String()]; ok {
+				return next(to)
+			}
+		}
+
+		return next(msg)
+	}
+}
+
+// Filter drops the messages for which keep returns false.
+func Filter(keep func(msg tea.Msg) bool) Middleware {
+	return func(msg tea.Msg, next Next) tea.Cmd {
+		if !keep(msg) {
+			return nil
+		}
+
+		return next(msg)
+	}
+}
+
+// ReadOnly drops key messages other than the allowed keys, and mouse
+// messages other than wheel events, so the model can be viewed and
+// scrolled but not edited.
+func ReadOnly(allow ...string) Middleware {
+	return Filter(func(msg tea.Msg) bool {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			return slices.Contains(allow, msg.String())
+		case tea.MouseMsg:
+			return tea.MouseEvent(msg).IsWheel()
+		default:
+			return true
+		}
+	})
+}
+
+// keyTypes maps the names of special keys to their [tea.KeyType].
+var keyTypes = sync.OnceValue(func() map[string]tea.KeyType {
+	types := make(map[string]tea.KeyType)
+	for t := tea.KeyType(-128); t <= 127; t++ {
+		if name := t.String(); name != "" && t != tea.KeyRunes {
+			types[name] = t
+		}
+	}
+
+	return types
+})
+
+// parseKey returns the [tea.KeyMsg] that [tea.KeyMsg.String] formats as s.
+func parseKey(s string) tea.KeyMsg {
+	if t, ok := keyTypes()[s]; ok {
+		return tea.KeyMsg{Type: t}
+	}
+
+	key := tea.Key{Type: tea.KeyRunes}
+	if rest, ok := strings.CutPrefix(s, "alt+"); ok && rest != "" {
+		key.Alt = true
+		s = rest
+		if t, ok := keyTypes()[s]; ok {
+			key.Type = t
+			return tea.KeyMsg(key)
+		}
+	}
+
+	key.Runes = []rune(s)
+	return tea.KeyMsg(key)
+}
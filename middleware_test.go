@@ -0,0 +1,65 @@
+package polymer
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseKeyRoundTrip(t *testing.T) {
+	for _, s := range []string{"a", "G", "?", "enter", "esc", "ctrl+c", "up", "shift+tab", " ", "alt+x", "alt+enter", "alt+ctrl+a"} {
+		if got := parseKey(s).String(); got != s {
+			t.Errorf("parseKey(%q).String() = %q", s, got)
+		}
+	}
+}
+
+func TestRemapKeys(t *testing.T) {
+	remap := RemapKeys(map[string]string{"j": "down", "k": "up", "alt+x": "ctrl+c"})
+	next, delivered := recordNext()
+
+	for _, msg := range []tea.Msg{runes("j"), runes("k"), parseKey("alt+x"), runes("q"), "tick"} {
+		remap(msg, next)
+	}
+
+	var got []string
+	for _, msg := range *delivered {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			got = append(got, key.String())
+		}
+	}
+	if want := []string{"down", "up", "ctrl+c", "q"}; !slices.Equal(got, want) {
+		t.Errorf("delivered keys %v, want %v", got, want)
+	}
+	if last := (*delivered)[len(*delivered)-1]; last != "tick" {
+		t.Errorf("other messages were not passed on: last %v", last)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	readOnly := ReadOnly("up", "down")
+	next, delivered := recordNext()
+
+	for _, msg := range []tea.Msg{
+		parseKey("up"),
+		runes("x"),
+		parseKey("down"),
+		tea.MouseMsg{Button: tea.MouseButtonWheelDown},
+		tea.MouseMsg{Button: tea.MouseButtonLeft, Action: tea.MouseActionPress},
+		"tick",
+	} {
+		readOnly(msg, next)
+	}
+
+	want := []tea.Msg{parseKey("up"), parseKey("down"), tea.MouseMsg{Button: tea.MouseButtonWheelDown}, "tick"}
+	if !slices.EqualFunc(*delivered, want, func(a, b tea.Msg) bool {
+		if key, ok := a.(tea.KeyMsg); ok {
+			other, ok := b.(tea.KeyMsg)
+			return ok && key.String() == other.String()
+		}
+		return a == b
+	}) {
+		t.Errorf("delivered %v, want %v", *delivered, want)
+	}
+}
@@ -0,0 +1,317 @@
+package polymer
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Placement positions an overlay relative to the view beneath it.
+type Placement struct {
+	Horizontal lipgloss.Position // Horizontal anchor, from [lipgloss.Left] to [lipgloss.Right].
+	Vertical   lipgloss.Position // Vertical anchor, from [lipgloss.Top] to [lipgloss.Bottom].
+	OffsetX    int               // Columns added after anchoring.
+	OffsetY    int               // Rows added after anchoring.
+}
+
+// Centered places an overlay in the middle of the view beneath it.
+var Centered = Placement{Horizontal: lipgloss.Center, Vertical: lipgloss.Center}
+
+// Anchored places an overlay at the given anchors.
+func Anchored(horizontal, vertical lipgloss.Position) Placement {
+	return Placement{Horizontal: horizontal, Vertical: vertical}
+}
+
+// Offset returns a copy of the Placement moved by dx columns and dy rows.
+func (p Placement) Offset(dx, dy int) Placement {
+	p.OffsetX += dx
+	p.OffsetY += dy
+	return p
+}
+
+// origin returns the top-left cell of a fgWidth x fgHeight box placed within width x height.
+func (p Placement) origin(width, height, fgWidth, fgHeight int) (x, y int) {
+	x = int(float64(width-fgWidth)*float64(p.Horizontal)) + p.OffsetX
+	y = int(float64(height-fgHeight)*float64(p.Vertical)) + p.OffsetY
+	return max(0, x), max(0, y)
+}
+
+// Overlay renders a floating [Atomic] on top of a base [Atomic].
+//
+// While the overlay is open it receives all key and mouse messages; other
+// messages are delivered to both. The overlay is dismissed when its Update
+// returns nil, or ends with [Done] or [Cancel], in which case its [ResultMsg]
+// is delivered to the base. The Overlay itself ends when its base does.
+//
+// Shown overlays are mounted, and dismissed or replaced overlays are disposed.
+type Overlay struct {
+	Atom
+	base      Atomic
+	overlay   Atomic
+	placement Placement
+	width     int
+	height    int
+}
+
+// NewOverlay creates a new [Overlay] over base.
+func NewOverlay(base Atomic) *Overlay {
+	if base == nil {
+		panic("base atom cannot be nil")
+	}
+
+	return &Overlay{
+		Atom: NewAtom("overlay"),
+		base: base,
+	}
+}
+
+// ShowOverlayMsg opens an [Atomic] in the nearest [Overlay]: the innermost
+// one on the [ActivePath].
+type ShowOverlayMsg struct {
+	Atom      Atomic
+	Placement Placement
+}
+
+// DismissOverlayMsg closes the open [Atomic] of the nearest [Overlay]: the
+// innermost open one on the [ActivePath].
+type DismissOverlayMsg struct{}
+
+// ShowOverlay sends a [ShowOverlayMsg].
+func ShowOverlay(atom Atomic, placement Placement) tea.Cmd {
+	return func() tea.Msg {
+		return ShowOverlayMsg{Atom: atom, Placement: placement}
+	}
+}
+
+// DismissOverlay sends a [DismissOverlayMsg].
+func DismissOverlay() tea.Cmd {
+	return func() tea.Msg {
+		return DismissOverlayMsg{}
+	}
+}
+
+// IsOpen reports whether an overlay is currently shown.
+func (o Overlay) IsOpen() bool { return o.overlay != nil }
+
+var _ Modal = Overlay{}
+
+// GetCurrent implements [Modal].
+// It returns the open overlay, or the base when no overlay is shown.
+func (o Overlay) GetCurrent() Atomic {
+	if o.overlay != nil {
+		return o.overlay
+	}
+
+	return o.base
+}
+
+// Children implements [Container].
+func (o Overlay) Children() []tea.Model {
+	if o.overlay != nil {
+		return []tea.Model{o.base, o.overlay}
+	}
+
+	return []tea.Model{o.base}
+}
+
+var _ Snapshotter = Overlay{}
+
+// Snapshot implements [Snapshotter].
+// Only the base is part of the snapshot; an open overlay is not.
+func (o Overlay) Snapshot() ([]byte, error) { return SnapshotModel(o.base) }
+
+// Restore implements [Snapshotter].
+func (o Overlay) Restore(data []byte) (tea.Model, error) {
+	base, err := RestoreModel(o.base, data)
+	if err != nil {
+		return o, err
+	}
+
+	o.base = AsAtomic(o.base, base)
+	return o, nil
+}
+
+// Init implements [tea.Model].
+func (o Overlay) Init() tea.Cmd { return o.base.Init() }
+
+// Update implements [tea.Model].
+func (o Overlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ShowOverlayMsg:
+		if o.nested(false) {
+			return o.updateCurrent(msg)
+		}
+		if msg.Atom == nil {
+			return o, nil
+		}
+		var dispose tea.Cmd
+		if o.overlay != nil {
+			dispose = Dispose(o.overlay)
+		}
+		o.overlay = msg.Atom
+		o.placement = msg.Placement
+		return o, tea.Batch(dispose, o.overlay.Init(), Mount(o.overlay), o.resize())
+
+	case DismissOverlayMsg:
+		if o.nested(true) {
+			return o.updateCurrent(msg)
+		}
+		var dispose tea.Cmd
+		if o.overlay != nil {
+			dispose = Dispose(o.overlay)
+		}
+		o.overlay = nil
+		return o, dispose
+
+	case tea.WindowSizeMsg:
+		o.width, o.height = msg.Width, msg.Height
+
+	case tea.KeyMsg, tea.MouseMsg:
+		if o.overlay != nil {
+			return o.updateOverlay(msg)
+		}
+	}
+
+	var overlayCmd tea.Cmd
+	if o.overlay != nil {
+		var next tea.Model
+		next, overlayCmd = Deliver(o.overlay, msg)
+		overlayCmd = tea.Batch(overlayCmd, o.setOverlay(next))
+	}
+
+	next, cmd := o.updateBase(msg)
+	return next, tea.Batch(overlayCmd, cmd)
+}
+
+// nested reports whether an [Overlay] on the active path below o, open if
+// open is set, is nearer to the active model and handles overlay messages.
+func (o Overlay) nested(open bool) bool {
+	for _, atom := range ActivePath(o.GetCurrent()) {
+		switch inner := atom.(type) {
+		case Overlay:
+			if !open || inner.IsOpen() {
+				return true
+			}
+		case *Overlay:
+			if !open || inner.IsOpen() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// updateCurrent delivers msg to the open overlay, or to the base when none is open.
+func (o Overlay) updateCurrent(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if o.overlay != nil {
+		return o.updateOverlay(msg)
+	}
+
+	return o.updateBase(msg)
+}
+
+// updateBase delivers msg to the base, ending the Overlay if the base exits.
+func (o Overlay) updateBase(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := Deliver(o.base, msg)
+	if _, ok := Exited(next); ok {
+		return next, cmd
+	}
+
+	o.base = AsAtomic(o.base, next)
+	return o, cmd
+}
+
+// updateOverlay delivers msg to the open overlay only.
+func (o Overlay) updateOverlay(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := o.overlay.Update(msg)
+	return o, tea.Batch(cmd, o.setOverlay(next))
+}
+
+// setOverlay stores next as the open overlay, disposing the overlay if it exited.
+func (o *Overlay) setOverlay(next tea.Model) tea.Cmd {
+	if result, ok := Exited(next); ok {
+		removed := o.overlay
+		o.overlay = nil
+		if result == nil {
+			return Dispose(removed)
+		}
+		return tea.Batch(Dispose(removed), SendTo(o.base.Id(), result))
+	}
+
+	o.overlay = AsAtomic(o.overlay, next)
+	return nil
+}
+
+// resize sends the last known window size to a newly opened overlay.
+func (o Overlay) resize() tea.Cmd {
+	if o.width == 0 && o.height == 0 {
+		return nil
+	}
+
+	return func() tea.Msg {
+		return tea.WindowSizeMsg{Width: o.width, Height: o.height}
+	}
+}
+
+// View implements [tea.Model].
+func (o Overlay) View() string {
+	view := o.base.View()
+	if o.overlay == nil {
+		return view
+	}
+
+	return Place(view, o.overlay.View(), o.placement, o.width, o.height)
+}
+
+// Place composites fg over bg at placement within a width x height area.
+// The area grows to fit bg when bg is larger.
+func Place(bg, fg string, placement Placement, width, height int) string {
+	bgWidth, bgHeight := lipgloss.Size(bg)
+	fgWidth, fgHeight := lipgloss.Size(fg)
+	x, y := placement.origin(max(width, bgWidth), max(height, bgHeight), fgWidth, fgHeight)
+	return Composite(bg, fg, x, y)
+}
+
+// Composite renders fg over bg with its top-left corner at column x and row y.
+// Both strings may contain ANSI escape sequences and wide characters; cells of
+// bg outside the area covered by fg are preserved.
+func Composite(bg, fg string, x, y int) string {
+	x, y = max(0, x), max(0, y)
+
+	bgLines := strings.Split(bg, "\n")
+	fgLines := strings.Split(fg, "\n")
+	fgWidth, _ := lipgloss.Size(fg)
+
+	for len(bgLines) < y+len(fgLines) {
+		bgLines = append(bgLines, "")
+	}
+
+	for i, fgLine := range fgLines {
+		bgLine := bgLines[y+i]
+
+		left := ansi.Truncate(bgLine, x, "")
+		if w := ansi.StringWidth(left); w < x {
+			left += strings.Repeat(" ", x-w)
+		}
+
+		if w := ansi.StringWidth(fgLine); w < fgWidth {
+			fgLine += strings.Repeat(" ", fgWidth-w)
+		}
+
+		right := ""
+		if rest := ansi.StringWidth(bgLine) - (x + fgWidth); rest > 0 {
+			right = ansi.TruncateLeft(bgLine, x+fgWidth, "")
+			if over := ansi.StringWidth(right) - rest; over > 0 {
+				// A wide character straddles the right edge of fg.
+				right = strings.Repeat(" ", over) + ansi.TruncateLeft(bgLine, x+fgWidth+over, "")
+			}
+		}
+
+		bgLines[y+i] = left + ansi.ResetStyle + fgLine + ansi.ResetStyle + right
+	}
+
+	return strings.Join(bgLines, "\n")
+}
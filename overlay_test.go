@@ -0,0 +1,50 @@
+package polymer
+
+import (
+	"testing"
+)
+
+func TestNestedOverlayReceivesShow(t *testing.T) {
+	inner := NewOverlay(newProbe("base"))
+	outer := NewOverlay(inner)
+	popup := newProbe("popup")
+
+	next, _ := outer.Update(ShowOverlayMsg{Atom: popup, Placement: Centered})
+	o := next.(Overlay)
+	if o.IsOpen() {
+		t.Fatalf("outer overlay opened the popup")
+	}
+	if got := o.GetCurrent().(Overlay); !got.IsOpen() || got.GetCurrent().Id() != popup.Id() {
+		t.Fatalf("inner overlay did not open the popup")
+	}
+
+	next, _ = o.Update(DismissOverlayMsg{})
+	if got := next.(Overlay).GetCurrent().(Overlay); got.IsOpen() {
+		t.Errorf("inner overlay was not dismissed")
+	}
+}
+
+func TestOpenOuterOverlayReceivesDismiss(t *testing.T) {
+	outer := NewOverlay(NewOverlay(newProbe("base")))
+	outer.overlay = newProbe("popup")
+
+	next, _ := outer.Update(DismissOverlayMsg{})
+	if next.(Overlay).IsOpen() {
+		t.Errorf("outer overlay was not dismissed")
+	}
+}
+
+func TestHostShowsOverlayInFocusedOverlayOnly(t *testing.T) {
+	a, b := NewOverlay(newProbe("a")), NewOverlay(newProbe("b"))
+	h := *newHost("host", NewFocusGroup("group", a, b))
+
+	next, _ := h.Update(ShowOverlayMsg{Atom: newProbe("popup"), Placement: Centered})
+	children := next.(Host).state.(FocusGroup).Children()
+
+	if !children[0].(Overlay).IsOpen() {
+		t.Error("focused overlay is not open")
+	}
+	if children[1].(*Overlay).IsOpen() {
+		t.Error("sibling overlay opened too")
+	}
+}
@@ -0,0 +1,186 @@
+package polymer
+
+import (
+	"maps"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/trippwill/polymer/util"
+)
+
+// Provider holds a value of type T for the models beneath it.
+//
+// Descendants request the value with [UseContext] and receive it as a
+// [util.ContextMsg], again whenever the value changes. When providers of the
+// same type are nested, the one nearest to the requester wins. Requesters
+// stop receiving the value once they are unmounted.
+type Provider[T any] struct {
+	Atom
+	child       Atomic
+	value       T
+	subscribers map[uint32]struct{}
+}
+
+// NewProvider creates a new [Provider] of value for child.
+func NewProvider[T any](name string, value T, child Atomic) *Provider[T] {
+	if child == nil {
+		panic("child atom cannot be nil")
+	}
+
+	return &Provider[T]{
+		Atom:        NewAtom(name),
+		child:       child,
+		value:       value,
+		subscribers: make(map[uint32]struct{}),
+	}
+}
+
+// ProvideMsg sets the value of a [Provider].
+// Send it to the provider with [SendTo], or use [Provide].
+type ProvideMsg[T any] struct {
+	Value T
+}
+
+// Provide sets the value of the [Provider] with the given id.
+func Provide[T any](provider uint32, value T) tea.Cmd {
+	return SendTo(provider, ProvideMsg[T]{Value: value})
+}
+
+// UseContext requests the value of the nearest [Provider] of T above the
+// [Atomic] with the given id. The value is delivered to that Atomic as a
+// [util.ContextMsg], and again whenever it changes. It is typically called
+// from Init.
+func UseContext[T any](id uint32) tea.Cmd {
+	return func() tea.Msg {
+		return contextLookupMsg[T]{target: id}
+	}
+}
+
+// contextLookupMsg travels from the Host to its target, collecting the value
+// of each [Provider] of T on the way so the nearest one is delivered.
+type contextLookupMsg[T any] struct {
+	target uint32
+	value  T
+	found  bool
+}
+
+var _ Routed = contextLookupMsg[any]{}
+
+// RouteTo implements [Routed].
+func (m contextLookupMsg[T]) RouteTo(child tea.Model) (tea.Msg, bool) {
+	if atom, ok := child.(HasId); ok && atom.Id() == m.target {
+		if !m.found {
+			return nil, false
+		}
+		return util.ContextMsg[T]{Context: m.value}, true
+	}
+
+	if _, ok := child.(Container); ok {
+		return m, Contains(child, m.target)
+	}
+
+	return m, isContainer(child)
+}
+
+// Value returns the value held by the Provider.
+func (p Provider[T]) Value() T { return p.value }
+
+var _ Modal = Provider[any]{}
+
+// GetCurrent implements [Modal].
+func (p Provider[T]) GetCurrent() Atomic { return p.child }
+
+// Children implements [Container].
+func (p Provider[T]) Children() []tea.Model { return []tea.Model{p.child} }
+
+var _ Snapshotter = Provider[any]{}
+
+// Snapshot implements [Snapshotter].
+// The value of the Provider is not part of the snapshot.
+func (p Provider[T]) Snapshot() ([]byte, error) { return SnapshotModel(p.child) }
+
+// Restore implements [Snapshotter].
+func (p Provider[T]) Restore(data []byte) (tea.Model, error) {
+	child, err := RestoreModel(p.child, data)
+	if err != nil {
+		return p, err
+	}
+
+	p.child = AsAtomic(p.child, child)
+	return p, nil
+}
+
+// Init implements [tea.Model].
+func (p Provider[T]) Init() tea.Cmd { return p.child.Init() }
+
+// Update implements [tea.Model].
+func (p Provider[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch m := msg.(type) {
+	case contextLookupMsg[T]:
+		if !Contains(p.child, m.target) {
+			break
+		}
+		if _, ok := p.subscribers[m.target]; !ok {
+			p.subscribers = maps.Clone(p.subscribers)
+			p.subscribers[m.target] = struct{}{}
+		}
+		m.value, m.found = p.value, true
+		msg = m
+
+	case UnmountMsg:
+		p.unsubscribe(m.Model)
+
+	case DisposeMsg:
+		p.unsubscribe(m.Model)
+
+	case ProvideMsg[T]:
+		p.value = m.Value
+		return p, p.notify()
+	}
+
+	next, cmd := Deliver(p.child, msg)
+	if _, ok := Exited(next); ok {
+		return next, cmd
+	}
+
+	p.child = AsAtomic(p.child, next)
+	return p, cmd
+}
+
+// unsubscribe removes the subscribers in the tree of model that are no
+// longer below the Provider.
+func (p *Provider[T]) unsubscribe(model tea.Model) {
+	var removed []uint32
+	Walk(model, func(m tea.Model, _ int) bool {
+		atom, ok := m.(HasId)
+		if !ok {
+			return true
+		}
+		if _, ok := p.subscribers[atom.Id()]; ok && !Contains(p.child, atom.Id()) {
+			removed = append(removed, atom.Id())
+		}
+		return true
+	})
+
+	if len(removed) == 0 {
+		return
+	}
+
+	p.subscribers = maps.Clone(p.subscribers)
+	for _, id := range removed {
+		delete(p.subscribers, id)
+	}
+}
+
+// notify repeats the lookup of every subscriber, so each receives the value
+// of its nearest [Provider].
+func (p Provider[T]) notify() tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(p.subscribers))
+	for id := range p.subscribers {
+		cmds = append(cmds, UseContext[T](id))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// View implements [tea.Model].
+func (p Provider[T]) View() string { return p.child.View() }
@@ -0,0 +1,41 @@
+package polymer
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/trippwill/polymer/util"
+)
+
+func TestProviderForgetsUnmountedSubscribers(t *testing.T) {
+	root, screen := newProbe("root"), newProbe("screen")
+	var model tea.Model = *NewProvider("theme", "dark", NewChain("chain", root))
+	model, _ = model.Update(PushMsg{Atom: screen})
+
+	for _, msg := range collect(UseContext[string](screen.Id())) {
+		model, _ = model.Update(msg)
+	}
+	if want := (util.ContextMsg[string]{Context: "dark"}); !screen.got(want) {
+		t.Fatalf("screen received %v, want %v", *screen.received, want)
+	}
+
+	subscribed := model.(Provider[string])
+	if len(subscribed.subscribers) != 1 {
+		t.Fatalf("%d subscribers, want 1", len(subscribed.subscribers))
+	}
+
+	model, cmd := model.Update(PopMsg{})
+	for _, msg := range collect(cmd) {
+		model, _ = model.Update(msg)
+	}
+
+	if got := len(model.(Provider[string]).subscribers); got != 0 {
+		t.Errorf("after pop: %d subscribers, want 0", got)
+	}
+	if len(subscribed.subscribers) != 1 {
+		t.Errorf("unsubscribing changed an earlier copy of the provider")
+	}
+	if _, cmd := model.Update(ProvideMsg[string]{Value: "light"}); cmd != nil {
+		t.Errorf("provider notified unmounted subscribers")
+	}
+}
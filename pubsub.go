@@ -0,0 +1,73 @@
+package polymer
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Topic is a named channel of events carrying a T.
+//
+//	var UserSaved = poly.Topic[User]("user-saved")
+type Topic[T any] string
+
+// AnyTopic is implemented by every [Topic].
+type AnyTopic interface {
+	TopicName() string
+}
+
+// TopicName implements [AnyTopic].
+func (t Topic[T]) TopicName() string { return string(t) }
+
+// Publish sends payload to the current subscribers of the Topic.
+func (t Topic[T]) Publish(payload T) tea.Cmd {
+	return Publish(t, payload)
+}
+
+// Subscriber is implemented by models that receive events published to topics.
+//
+// Subscriptions is consulted each time an event is delivered, so a model can
+// change its subscriptions as its state changes.
+type Subscriber interface {
+	Subscriptions() []AnyTopic
+}
+
+// Event is a payload published to a [Topic].
+//
+// An Event is delivered to every [Subscriber] of its topic that is held by a
+// container, including children that are not the active child of a [Modal],
+// and to no other leaves.
+type Event[T any] struct {
+	Topic   Topic[T]
+	Payload T
+}
+
+// Publish sends an [Event] to the current subscribers of topic.
+func Publish[T any](topic Topic[T], payload T) tea.Cmd {
+	return func() tea.Msg {
+		return Event[T]{Topic: topic, Payload: payload}
+	}
+}
+
+var _ Routed = Event[any]{}
+
+// RouteTo implements [Routed].
+func (e Event[T]) RouteTo(child tea.Model) (tea.Msg, bool) {
+	if Subscribes(child, e.Topic) || isContainer(child) {
+		return e, true
+	}
+
+	return nil, false
+}
+
+// Subscribes reports whether model is a [Subscriber] of topic.
+func Subscribes(model tea.Model, topic AnyTopic) bool {
+	subscriber, ok := model.(Subscriber)
+	if !ok {
+		return false
+	}
+
+	return slices.ContainsFunc(subscriber.Subscriptions(), func(t AnyTopic) bool {
+		return t.TopicName() == topic.TopicName()
+	})
+}
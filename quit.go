@@ -0,0 +1,139 @@
+package polymer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Dirty is implemented by models that may hold unsaved changes.
+// See [WithQuitConfirmation].
+type Dirty interface {
+	Dirty() bool
+}
+
+// IsDirty reports whether any model in the tree of root is [Dirty].
+func IsDirty(root tea.Model) bool {
+	dirty := false
+	Walk(root, func(model tea.Model, _ int) bool {
+		if d, ok := model.(Dirty); ok && d.Dirty() {
+			dirty = true
+		}
+		return !dirty
+	})
+
+	return dirty
+}
+
+// WithQuitKeys sets the keys that quit the Host, replacing the keys of the
+// global quit action, ctrl+c by default. With no keys the Host can only be
+// quit by the models it holds.
+//
+// The keys apply to this Host only. Keys that type text are left to a
+// focused [TextInput].
+func WithQuitKeys(keys ...string) HostOption {
+	return func(h *Host) {
+		binding := key.NewBinding(key.WithDisabled())
+		if len(keys) > 0 {
+			binding = key.NewBinding(
+				key.WithKeys(keys...),
+				key.WithHelp(strings.Join(keys, "/"), quitKey.Binding().Help().Desc),
+			)
+		}
+		h.quitKeys = &binding
+	}
+}
+
+// quitBinding returns the keys that quit the Host.
+func (h Host) quitBinding() key.Binding {
+	if h.quitKeys != nil {
+		return *h.quitKeys
+	}
+
+	return quitKey.Binding()
+}
+
+// WithQuitConfirmation asks the user to confirm quitting with prompt when
+// any model in the tree is [Dirty]. By default quitting is confirmed with y
+// or enter and abandoned with n or esc.
+func WithQuitConfirmation(prompt string) HostOption {
+	return func(h *Host) {
+		h.confirmPrompt = prompt
+	}
+}
+
+// QuitMsg requests that the [Host] quit with an exit code. See [Run].
+type QuitMsg struct {
+	Code int
+}
+
+// Quit sends a [QuitMsg] with [ExitOK].
+// Unlike [tea.Quit] it is subject to [WithQuitConfirmation].
+func Quit() tea.Cmd {
+	return Exit(ExitOK)
+}
+
+// Exit sends a [QuitMsg] with code.
+func Exit(code int) tea.Cmd {
+	return func() tea.Msg {
+		return QuitMsg{Code: code}
+	}
+}
+
+var confirmStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	Padding(0, 1)
+
+// requestQuit quits the Host with code, or asks for confirmation if the tree is dirty.
+func (h Host) requestQuit(code int) (tea.Model, tea.Cmd) {
+	if h.confirmPrompt != "" && IsDirty(h.state) {
+		h.confirming, h.pendingCode = true, code
+		return h, nil
+	}
+
+	h.code = code
+	return h.quit(false)
+}
+
+// quit disposes the tree and quits the program, ended reporting whether the
+// root ended by itself rather than the user quitting.
+// The Host keeps its result and exit code but no longer holds a tree.
+func (h Host) quit(ended bool) (Host, tea.Cmd) {
+	if h.snapshotFile != "" {
+		if ended {
+			h.snapshotErr = h.removeSnapshot()
+		} else {
+			h.snapshotErr = h.saveSnapshot()
+		}
+	}
+
+	// The DisposeMsg is delivered now, while the Host still holds the tree,
+	// so a Lens around the root observes it.
+	unmount := dispose(h.state)
+	_, disposed := Deliver(h.state, DisposeMsg{Model: h.state})
+	h.state, h.dialog, h.confirming = nil, nil, false
+	return h, tea.Batch(unmount, disposed, tea.Quit)
+}
+
+// updateConfirm handles key messages while the quit confirmation is shown.
+func (h Host) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case confirmQuitKey.Matches(msg):
+		h.code = h.pendingCode
+		return h.quit(false)
+	case cancelQuitKey.Matches(msg):
+		h.confirming = false
+	}
+
+	return h, nil
+}
+
+// viewConfirm renders the quit confirmation over view.
+func (h Host) viewConfirm(view string) string {
+	dialog := confirmStyle.Render(fmt.Sprintf("%s\n\n%s quit • %s cancel",
+		h.confirmPrompt, helpKey(confirmQuitKey), helpKey(cancelQuitKey)))
+	return Place(view, dialog, Centered, h.width, h.height)
+}
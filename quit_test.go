@@ -0,0 +1,63 @@
+package polymer
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWithQuitKeys(t *testing.T) {
+	h := *newHost("host", newProbe("root"), WithQuitKeys("q"))
+
+	if got := h.KeyMap()[0].Help().Key; got != "q" {
+		t.Errorf("quit help key = %q, want q", got)
+	}
+	if next := press(h, tea.KeyMsg{Type: tea.KeyCtrlC}); next.state == nil {
+		t.Errorf("ctrl+c quit the host")
+	}
+	if next := press(h, runes("q")); next.state != nil {
+		t.Errorf("q did not quit the host")
+	}
+
+	other := *newHost("other", newProbe("root"))
+	if next := press(other, tea.KeyMsg{Type: tea.KeyCtrlC}); next.state != nil {
+		t.Errorf("ctrl+c did not quit a host without quit keys")
+	}
+}
+
+func TestTextInputTakesQuitKeys(t *testing.T) {
+	leaf := typist{newProbe("leaf")}
+	h := press(*newHost("host", leaf, WithQuitKeys("q", "ctrl+c")), runes("q"))
+	if h.state == nil || !leaf.gotKey("q") {
+		t.Errorf("typing q quit the host, leaf received %v", *leaf.received)
+	}
+
+	h = press(h, tea.KeyMsg{Type: tea.KeyCtrlC})
+	if h.state != nil {
+		t.Errorf("ctrl+c did not quit the host")
+	}
+}
+
+func TestDialogTakesQuitKeys(t *testing.T) {
+	dialog := typist{newProbe("help")}
+	h := press(*newHost("host", newProbe("root"), WithQuitKeys("q"), WithHelp(func([]KeyGroup) tea.Model {
+		return dialog
+	})), runes("?"), runes("q"))
+
+	if h.state == nil || !dialog.gotKey("q") {
+		t.Errorf("typing q into the dialog quit the host, dialog received %v", *dialog.received)
+	}
+}
+
+func TestQuitDeliversDispose(t *testing.T) {
+	root := newProbe("root")
+	var disposed tea.Model
+	h := *newHost("host", root, WithLens(WithOnDispose(func(model tea.Model) {
+		disposed = model
+	})))
+
+	h.quit(false)
+	if lens, ok := disposed.(*Lens); !ok || lens.Model.(probe).Id() != root.Id() {
+		t.Errorf("OnDispose saw %T, want the lens around the root", disposed)
+	}
+}
@@ -0,0 +1,84 @@
+package polymer
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ResultMsg carries the result of an [Atomic] that ended itself with [Done]
+// or [Cancel] to the model that launched it.
+//
+// A [Chain] delivers it to the atom beneath the finished one, an [Overlay]
+// to its base. Containers whose children are given to them, such as menus
+// and focus groups, forward it to the containers above them with [Bubble].
+// Use [OpenResult] to receive it in either form.
+type ResultMsg[T any] struct {
+	Source    uint32 // Id of the Atomic that finished.
+	Value     T      // The result, or the zero value if Cancelled.
+	Cancelled bool   // Whether the Atomic was cancelled rather than completed.
+}
+
+// Done ends source with value as its result.
+// Return it from Update in place of the model:
+//
+//	return poly.Done(s, selection), nil
+func Done[T any](source HasId, value T) tea.Model {
+	return result[T]{msg: ResultMsg[T]{Source: source.Id(), Value: value}}
+}
+
+// Cancel ends source without a result of type T.
+// Return it from Update in place of the model.
+func Cancel[T any](source HasId) tea.Model {
+	return result[T]{msg: ResultMsg[T]{Source: source.Id(), Cancelled: true}}
+}
+
+// Exited reports whether next, as returned from Update, ends the model:
+// either nil or the model returned by [Done] or [Cancel]. For the latter
+// it also returns the [ResultMsg] to deliver to the launcher.
+func Exited(next tea.Model) (tea.Msg, bool) {
+	switch next := next.(type) {
+	case nil:
+		return nil, true
+	case outcome:
+		return next.result(), true
+	default:
+		return nil, false
+	}
+}
+
+// OpenResult returns the [ResultMsg] of T in msg, whether it was delivered
+// directly or bubbled in an [Envelope].
+func OpenResult[T any](msg tea.Msg) (ResultMsg[T], bool) {
+	if result, ok := msg.(ResultMsg[T]); ok {
+		return result, true
+	}
+
+	result, _, ok := Open[ResultMsg[T]](msg)
+	return result, ok
+}
+
+// isCancelled reports whether msg is a [ResultMsg] of a cancelled model.
+func isCancelled(msg tea.Msg) bool {
+	result, ok := msg.(interface{ cancelled() bool })
+	return ok && result.cancelled()
+}
+
+func (r ResultMsg[T]) cancelled() bool { return r.Cancelled }
+
+// outcome is implemented by the models returned from [Done] and [Cancel].
+type outcome interface {
+	tea.Model
+	result() tea.Msg
+}
+
+// result is a finished model carrying its [ResultMsg].
+// Containers replace it with the message; it is never rendered.
+type result[T any] struct {
+	msg ResultMsg[T]
+}
+
+var _ outcome = result[any]{}
+
+func (r result[T]) result() tea.Msg                     { return r.msg }
+func (r result[T]) Init() tea.Cmd                       { return nil }
+func (r result[T]) Update(tea.Msg) (tea.Model, tea.Cmd) { return r, nil }
+func (r result[T]) View() string                        { return "" }
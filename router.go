@@ -0,0 +1,114 @@
+package polymer
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ErrRouteNotFound is returned when a path is not registered with a [Router].
+var ErrRouteNotFound = errors.New("route not found")
+
+// Params are the named parameters passed to a [RouteFactory].
+type Params map[string]string
+
+// Get returns the value of the named parameter, or an empty string.
+func (p Params) Get(name string) string { return p[name] }
+
+// RouteFactory creates the [Atomic] for a route.
+type RouteFactory func(params Params) Atomic
+
+// Router is a registry of [Atomic] factories by path.
+type Router struct {
+	routes map[string]RouteFactory
+}
+
+// NewRouter creates a new empty [Router].
+func NewRouter() *Router {
+	return &Router{
+		routes: make(map[string]RouteFactory),
+	}
+}
+
+// Register registers factory at path.
+// Paths are slash separated, e.g. "settings/network".
+func (r *Router) Register(path string, factory RouteFactory) *Router {
+	path = cleanPath(path)
+	if path == "" {
+		panic("route path cannot be empty")
+	}
+	if factory == nil {
+		panic("route factory cannot be nil")
+	}
+	if _, exists := r.routes[path]; exists {
+		panic("route already registered: " + path)
+	}
+
+	r.routes[path] = factory
+	return r
+}
+
+// Resolve creates the [Atomic] registered at path.
+func (r *Router) Resolve(path string, params Params) (Atomic, error) {
+	factory, ok := r.routes[cleanPath(path)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrRouteNotFound, path)
+	}
+
+	atom := factory(params)
+	if atom == nil {
+		return nil, fmt.Errorf("route %q returned a nil atom", path)
+	}
+
+	return atom, nil
+}
+
+// Routes returns the registered paths in sorted order.
+func (r *Router) Routes() []string {
+	paths := make([]string, 0, len(r.routes))
+	for path := range r.routes {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// NavigateMsg requests navigation to a registered route.
+type NavigateMsg struct {
+	Path   string
+	Params Params
+}
+
+// Navigate sends a [NavigateMsg].
+func Navigate(path string, params Params) tea.Cmd {
+	return func() tea.Msg {
+		return NavigateMsg{Path: path, Params: params}
+	}
+}
+
+// RouteFromArgs derives a route path and parameters from command-line arguments.
+// Arguments of the form key=value (optionally prefixed with dashes) become
+// parameters; the remaining arguments are joined with "/" to form the path.
+func RouteFromArgs(args []string) (string, Params) {
+	var segments []string
+	params := make(Params)
+	for _, arg := range args {
+		if key, value, ok := strings.Cut(strings.TrimLeft(arg, "-"), "="); ok {
+			params[key] = value
+			continue
+		}
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		segments = append(segments, arg)
+	}
+
+	return cleanPath(strings.Join(segments, "/")), params
+}
+
+func cleanPath(path string) string {
+	return strings.Trim(path, "/")
+}
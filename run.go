@@ -0,0 +1,91 @@
+package polymer
+
+import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/trippwill/polymer/util"
+)
+
+// Exit codes returned by [Run].
+const (
+	ExitOK        = 0   // The root finished, or the program quit normally.
+	ExitFailure   = 1   // The program or a shutdown hook failed.
+	ExitCancelled = 130 // The root was cancelled, or the user quit with a quit key.
+)
+
+// WithShutdown adds a hook that [Run] calls after the program exits.
+// Hooks run in the order they were added, even if the program failed.
+func WithShutdown(hook func() error) HostOption {
+	return func(h *Host) {
+		h.shutdown = append(h.shutdown, hook)
+	}
+}
+
+// WithProgramOptions adds options for the [tea.Program] started by [Run].
+func WithProgramOptions(options ...tea.ProgramOption) HostOption {
+	return func(h *Host) {
+		h.programOptions = append(h.programOptions, options...)
+	}
+}
+
+// Run runs a [Host] for root until it quits, and returns the value the root
+// ended with through [Done], along with an exit code for the process.
+//
+// The value is the zero value of T if the root ended without a result or
+// was cancelled. Errors from the program, from saving the snapshot file and
+// from shutdown hooks are returned with [ExitFailure].
+//
+//	name, code, err := poly.Run[string]("Wizard", root)
+//	if err != nil {
+//		fmt.Fprintln(os.Stderr, err)
+//	}
+//	os.Exit(code)
+func Run[T any](name string, root tea.Model, options ...HostOption) (T, int, error) {
+	host := newHost(name, root, options...)
+	if host.alloc != nil {
+		defer util.SetAllocator(host.alloc)()
+	}
+
+	final, err := tea.NewProgram(host, host.programOptions...).Run()
+
+	var value T
+	code := ExitOK
+	if err == nil {
+		value, code, err = outcomeOf[T](final)
+	}
+
+	for _, hook := range host.shutdown {
+		err = errors.Join(err, hook())
+	}
+
+	if err != nil {
+		return value, ExitFailure, err
+	}
+
+	return value, code, nil
+}
+
+// outcomeOf returns the value and exit code the Host ended with.
+func outcomeOf[T any](final tea.Model) (T, int, error) {
+	var zero T
+	var h Host
+	switch final := final.(type) {
+	case Host:
+		h = final
+	case *Host:
+		h = *final
+	default:
+		return zero, ExitFailure, fmt.Errorf("unexpected final model %T", final)
+	}
+
+	switch result := h.result.(type) {
+	case nil:
+		return zero, h.code, h.snapshotErr
+	case ResultMsg[T]:
+		return result.Value, h.code, h.snapshotErr
+	default:
+		return zero, ExitFailure, fmt.Errorf("root ended with %T, want %T", result, ResultMsg[T]{})
+	}
+}
@@ -0,0 +1,60 @@
+package polymer
+
+import (
+	"context"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// scopes holds the lifetime context of each [Atomic] by id.
+var scopes sync.Map // map[uint32]*scope
+
+type scope struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// Context returns the lifetime context of owner.
+//
+// The context is cancelled when owner is unmounted or disposed by its
+// container. See [Unmount] and [Dispose]. A model that is mounted again
+// receives a new context.
+func Context(owner HasId) context.Context {
+	if s, ok := scopes.Load(owner.Id()); ok {
+		return s.(*scope).ctx
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s, loaded := scopes.LoadOrStore(owner.Id(), &scope{ctx: ctx, cancel: cancel})
+	if loaded {
+		cancel()
+	}
+
+	return s.(*scope).ctx
+}
+
+// Go returns a command that runs fn with the lifetime context of owner
+// and delivers its result to owner only.
+//
+// The result is dropped if the context was cancelled before fn returned,
+// or if owner is no longer in the tree, so it never reaches another model.
+func Go(owner HasId, fn func(ctx context.Context) tea.Msg) tea.Cmd {
+	id := owner.Id()
+	ctx := Context(owner)
+	return func() tea.Msg {
+		msg := fn(ctx)
+		if msg == nil || ctx.Err() != nil {
+			return nil
+		}
+
+		return Envelope{Target: id, Msg: msg}
+	}
+}
+
+// cancelScope cancels the lifetime context of the model with the given id.
+func cancelScope(id uint32) {
+	if s, ok := scopes.LoadAndDelete(id); ok {
+		s.(*scope).cancel()
+	}
+}
@@ -0,0 +1,74 @@
+package polymer
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// MouseMode selects which mouse events the program receives.
+type MouseMode int
+
+const (
+	MouseOff        MouseMode = iota // No mouse events.
+	MouseCellMotion                  // Clicks, wheel and drags.
+	MouseAllMotion                   // Clicks, wheel and all movement.
+)
+
+// WithAltScreen starts the Host in the alternate screen buffer
+// instead of inline with the terminal output.
+func WithAltScreen() HostOption {
+	return func(h *Host) {
+		h.altScreen = true
+	}
+}
+
+// WithMouse starts the Host capturing mouse events in the given mode.
+func WithMouse(mode MouseMode) HostOption {
+	return func(h *Host) {
+		h.mouse = mode
+	}
+}
+
+// AltScreenMsg requests that the [Host] enter or leave the alternate screen.
+type AltScreenMsg struct {
+	Enabled bool
+}
+
+// MouseMsg requests that the [Host] capture mouse events in the given mode.
+type MouseMsg struct {
+	Mode MouseMode
+}
+
+// AltScreen sends an [AltScreenMsg].
+func AltScreen(enabled bool) tea.Cmd {
+	return func() tea.Msg {
+		return AltScreenMsg{Enabled: enabled}
+	}
+}
+
+// Mouse sends a [MouseMsg].
+func Mouse(mode MouseMode) tea.Cmd {
+	return func() tea.Msg {
+		return MouseMsg{Mode: mode}
+	}
+}
+
+// altScreenCmd returns the command that enters or leaves the alternate screen.
+func altScreenCmd(enabled bool) tea.Cmd {
+	if enabled {
+		return tea.EnterAltScreen
+	}
+
+	return tea.ExitAltScreen
+}
+
+// mouseCmd returns the command that switches to mode.
+func mouseCmd(mode MouseMode) tea.Cmd {
+	switch mode {
+	case MouseCellMotion:
+		return tea.EnableMouseCellMotion
+	case MouseAllMotion:
+		return tea.EnableMouseAllMotion
+	default:
+		return tea.DisableMouse
+	}
+}
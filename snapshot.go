@@ -0,0 +1,105 @@
+package polymer
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Snapshotter is implemented by models that can save their state and
+// restore it in a later run. Snapshots are JSON, so containers can nest the
+// snapshots of their children.
+//
+// Containers implement it by including the snapshots of their children,
+// taken with [SnapshotModel] and restored with [RestoreModel], so a tree
+// can be saved from its root; containers that add no state of their own
+// return the snapshot of their child. Ids are not stable across runs and
+// should not be part of a snapshot.
+type Snapshotter interface {
+	// Snapshot returns the state of the model.
+	Snapshot() ([]byte, error)
+	// Restore returns the model with the state from a snapshot.
+	Restore(data []byte) (tea.Model, error)
+}
+
+// SnapshotModel returns the snapshot of model, or nil if it is not a [Snapshotter].
+func SnapshotModel(model tea.Model) ([]byte, error) {
+	snapshotter, ok := model.(Snapshotter)
+	if !ok {
+		return nil, nil
+	}
+
+	return snapshotter.Snapshot()
+}
+
+// RestoreModel returns model restored from data. The model is returned
+// unchanged if data is empty or the model is not a [Snapshotter].
+func RestoreModel(model tea.Model, data []byte) (tea.Model, error) {
+	snapshotter, ok := model.(Snapshotter)
+	if !ok || len(data) == 0 || string(data) == "null" {
+		return model, nil
+	}
+
+	restored, err := snapshotter.Restore(data)
+	if err != nil {
+		return model, fmt.Errorf("restore %s: %w", formatModel(model), err)
+	}
+
+	return restored, nil
+}
+
+// WithSnapshotFile restores the tree of the Host from the snapshot at path
+// when it starts, and saves the snapshot to path when the user quits.
+//
+// When the root ends by itself the flow is complete and the file is
+// removed, so the next run starts afresh. Errors restoring are reported
+// through the error hooks; errors saving are returned by [Run].
+//
+// Atoms pushed onto a [Chain] are not saved. See [Chain.Snapshot].
+func WithSnapshotFile(path string) HostOption {
+	return func(h *Host) {
+		h.snapshotFile = path
+	}
+}
+
+// restoreSnapshot restores the tree of the Host from its snapshot file.
+func (h *Host) restoreSnapshot() error {
+	data, err := os.ReadFile(h.snapshotFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	h.state, err = RestoreModel(h.state, data)
+	return err
+}
+
+// saveSnapshot saves the snapshot of the tree of the Host to its snapshot file.
+func (h Host) saveSnapshot() error {
+	data, err := SnapshotModel(h.state)
+	if err != nil || data == nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.snapshotFile), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(h.snapshotFile, data, 0o600)
+}
+
+// removeSnapshot removes the snapshot file of the Host.
+func (h Host) removeSnapshot() error {
+	err := os.Remove(h.snapshotFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,393 @@
+package polymer
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/trippwill/polymer/keymap"
+)
+
+// Direction is the axis along which a [Split] lays out its panes.
+type Direction int
+
+const (
+	Horizontal Direction = iota // Panes are placed side by side.
+	Vertical                    // Panes are stacked top to bottom.
+)
+
+type sizeKind int
+
+const (
+	sizeFlex sizeKind = iota
+	sizeFixed
+	sizePercent
+)
+
+// Size describes how much space a pane receives along the axis of a [Split].
+type Size struct {
+	kind  sizeKind
+	value int
+	min   int
+}
+
+// Fixed sizes a pane to exactly cells.
+func Fixed(cells int) Size { return Size{kind: sizeFixed, value: max(0, cells)} }
+
+// Percent sizes a pane to a percentage of the available space.
+func Percent(percent int) Size { return Size{kind: sizePercent, value: min(100, max(0, percent))} }
+
+// Flex sizes a pane to a share of the space left over by fixed and percentage panes,
+// proportional to weight.
+func Flex(weight int) Size { return Size{kind: sizeFlex, value: max(1, weight)} }
+
+// Min returns a copy of the Size that is never smaller than cells, unless
+// the minimums of the panes add up to more than the [Split] has.
+func (s Size) Min(cells int) Size {
+	s.min = max(0, cells)
+	return s
+}
+
+// Pane is a child of a [Split] and its size.
+type Pane struct {
+	Atom Atomic
+	Size Size
+}
+
+// NewPane creates a new [Pane].
+func NewPane(atom Atomic, size Size) Pane {
+	return Pane{Atom: atom, Size: size}
+}
+
+// paneLayout is the size of a pane of a [Split] and the offset by which
+// its divider has been moved.
+type paneLayout struct {
+	size   Size
+	offset int
+}
+
+// Split lays out its panes along a [Direction] and sends each pane a
+// [tea.WindowSizeMsg] for its own area.
+//
+// Focus and message delivery follow [FocusGroup]. The divider after the
+// focused pane is moved with ctrl+left/ctrl+right for [Horizontal] splits
+// and ctrl+up/ctrl+down for [Vertical] splits.
+type Split struct {
+	FocusGroup
+	panes     []paneLayout // layout of each child, by position
+	direction Direction
+	border    *lipgloss.Border
+	width     int
+	height    int
+}
+
+// NewSplit creates a new [Split] with the given panes.
+func NewSplit(name string, direction Direction, panes ...Pane) *Split {
+	children := make([]Atomic, len(panes))
+	layouts := make([]paneLayout, len(panes))
+	for i, pane := range panes {
+		children[i] = pane.Atom
+		layouts[i] = paneLayout{size: pane.Size}
+	}
+
+	return &Split{
+		FocusGroup: *NewFocusGroup(name, children...),
+		panes:      layouts,
+		direction:  direction,
+	}
+}
+
+// SetBorder draws border around each pane.
+func (s *Split) SetBorder(border lipgloss.Border) {
+	s.border = &border
+}
+
+var (
+	_ Modal       = Split{}
+	_ Snapshotter = Split{}
+)
+
+// splitSnapshot is the snapshot of a [Split].
+type splitSnapshot struct {
+	Group   json.RawMessage `json:"group"`
+	Offsets []int           `json:"offsets"` // Divider offsets of each pane, by position.
+}
+
+// Snapshot implements [Snapshotter].
+func (s Split) Snapshot() ([]byte, error) {
+	group, err := s.FocusGroup.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	offsets := make([]int, len(s.panes))
+	for i, pane := range s.panes {
+		offsets[i] = pane.offset
+	}
+
+	return json.Marshal(splitSnapshot{Group: group, Offsets: offsets})
+}
+
+// Restore implements [Snapshotter].
+func (s Split) Restore(data []byte) (tea.Model, error) {
+	var snapshot splitSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return s, err
+	}
+
+	group, err := s.FocusGroup.restore(snapshot.Group)
+	if err != nil {
+		return s, err
+	}
+
+	s.FocusGroup = group
+	s.panes = slices.Clone(s.panes)
+	for i := range s.panes {
+		if i < len(snapshot.Offsets) {
+			s.panes[i].offset = snapshot.Offsets[i]
+		}
+	}
+
+	return s, nil
+}
+
+var _ KeyMapProvider = Split{}
+
+// KeyMap implements [KeyMapProvider].
+func (s Split) KeyMap() []key.Binding {
+	grow, shrink := s.dividerKeys()
+	return append(s.FocusGroup.KeyMap(), grow.Binding(), shrink.Binding())
+}
+
+// dividerKeys returns the actions that move the divider for the direction of the Split.
+func (s Split) dividerKeys() (grow, shrink keymap.Action) {
+	if s.direction == Vertical {
+		return growVerticalKey, shrinkVerticalKey
+	}
+
+	return growKey, shrinkKey
+}
+
+// Update implements [tea.Model].
+func (s Split) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.width, s.height = msg.Width, msg.Height
+		return s.resize()
+
+	case tea.KeyMsg:
+		grow, shrink := s.dividerKeys()
+		switch {
+		case grow.Matches(msg):
+			s.moveDivider(1)
+			return s.resize()
+		case shrink.Matches(msg):
+			s.moveDivider(-1)
+			return s.resize()
+		}
+	}
+
+	before := s.children
+
+	var cmd tea.Cmd
+	s.FocusGroup, cmd = s.FocusGroup.update(msg)
+	if len(s.children) == 0 {
+		return s.ended, cmd
+	}
+
+	if len(s.children) != len(before) {
+		s.panes = s.retain(before)
+		next, resizeCmd := s.resize()
+		return next, tea.Batch(cmd, resizeCmd)
+	}
+
+	return s, cmd
+}
+
+// retain returns the layouts of the panes whose children remain after an
+// update removed some of the children before it. Children keep their order,
+// so each is matched to the next pane with its id, or to the next pane if
+// it returned a new [Atomic].
+func (s Split) retain(before []Atomic) []paneLayout {
+	panes := make([]paneLayout, 0, len(s.children))
+	i := 0
+	for _, child := range s.children {
+		if j := slices.IndexFunc(before[i:], func(atom Atomic) bool {
+			return atom.Id() == child.Id()
+		}); j >= 0 {
+			i += j
+		}
+		panes = append(panes, s.panes[i])
+		i++
+	}
+
+	return panes
+}
+
+// resize sends every pane a [tea.WindowSizeMsg] for its area.
+func (s Split) resize() (tea.Model, tea.Cmd) {
+	if s.width == 0 && s.height == 0 {
+		return s, nil
+	}
+
+	before := s.children
+	cmds := make([]tea.Cmd, 0, len(before))
+	for i, size := range s.layout() {
+		width, height := s.paneSize(size)
+		var cmd tea.Cmd
+		s.FocusGroup, cmd = s.updateChild(s.indexOf(before[i].Id()), tea.WindowSizeMsg{Width: width, Height: height})
+		cmds = append(cmds, cmd)
+	}
+
+	if len(s.children) == 0 {
+		return s.ended, tea.Batch(cmds...)
+	}
+
+	// Panes that exited on resizing leave their space to the others.
+	if len(s.children) != len(before) {
+		s.panes = s.retain(before)
+		next, cmd := s.resize()
+		return next, tea.Batch(append(cmds, cmd)...)
+	}
+
+	return s, tea.Batch(cmds...)
+}
+
+// moveDivider moves the divider after the focused pane by delta cells,
+// keeping both neighbouring panes at or above their minimum size.
+func (s *Split) moveDivider(delta int) {
+	if len(s.children) < 2 {
+		return
+	}
+
+	left := min(s.focused, len(s.children)-2)
+	right := left + 1
+	sizes := s.layout()
+	if sizes[left]+delta < max(1, s.panes[left].size.min) || sizes[right]-delta < max(1, s.panes[right].size.min) {
+		return
+	}
+
+	s.panes = slices.Clone(s.panes)
+	s.panes[left].offset += delta
+	s.panes[right].offset -= delta
+}
+
+// layout returns the size of each pane along the split axis.
+func (s Split) layout() []int {
+	total := s.width
+	if s.direction == Vertical {
+		total = s.height
+	}
+
+	sizes := make([]int, len(s.panes))
+	remaining, weights := total, 0
+	for i, pane := range s.panes {
+		switch size := pane.size; size.kind {
+		case sizeFixed:
+			sizes[i] = size.value
+		case sizePercent:
+			sizes[i] = total * size.value / 100
+		case sizeFlex:
+			weights += size.value
+			continue
+		}
+		remaining -= sizes[i]
+	}
+
+	// Flex panes share what is left in proportion to their weights. A zero
+	// weight only comes from the zero Size, which receives nothing.
+	if weights > 0 {
+		remaining = max(0, remaining)
+		last := -1
+		distributed := 0
+		for i, pane := range s.panes {
+			if size := pane.size; size.kind == sizeFlex {
+				sizes[i] = remaining * size.value / weights
+				distributed += sizes[i]
+				last = i
+			}
+		}
+		if last >= 0 {
+			sizes[last] += remaining - distributed
+		}
+	}
+
+	for i, pane := range s.panes {
+		sizes[i] = max(pane.size.min, sizes[i]+pane.offset, 0)
+	}
+
+	// Panes grown to their minimum take the space from flex panes first,
+	// then from the other panes down to their minimum, and finally from the
+	// last panes, so the panes never overflow the Split.
+	excess := -total
+	for _, size := range sizes {
+		excess += size
+	}
+	excess = shrink(sizes, excess, func(i int) int {
+		if s.panes[i].size.kind != sizeFlex {
+			return sizes[i]
+		}
+		return s.panes[i].size.min
+	})
+	excess = shrink(sizes, excess, func(i int) int { return s.panes[i].size.min })
+	shrink(sizes, excess, func(int) int { return 0 })
+
+	return sizes
+}
+
+// shrink takes up to excess cells from sizes, last first, keeping each
+// size at or above floor(i). It returns the cells it could not take.
+func shrink(sizes []int, excess int, floor func(i int) int) int {
+	for i := len(sizes) - 1; i >= 0 && excess > 0; i-- {
+		cut := min(excess, max(0, sizes[i]-floor(i)))
+		sizes[i] -= cut
+		excess -= cut
+	}
+
+	return excess
+}
+
+// paneSize returns the content width and height of a pane of size cells along the split axis.
+func (s Split) paneSize(size int) (width, height int) {
+	width, height = size, s.height
+	if s.direction == Vertical {
+		width, height = s.width, size
+	}
+
+	if s.border != nil {
+		width, height = max(0, width-2), max(0, height-2)
+	}
+
+	return width, height
+}
+
+// View implements [tea.Model].
+func (s Split) View() string {
+	sizes := s.layout()
+	views := make([]string, len(s.children))
+	for i, child := range s.children {
+		width, height := s.paneSize(sizes[i])
+		style := lipgloss.NewStyle().
+			Width(width).MaxWidth(width).
+			Height(height).MaxHeight(height)
+		view := style.Render(child.View())
+
+		if s.border != nil {
+			border := lipgloss.NewStyle().Border(*s.border)
+			if i == s.focused {
+				border = border.BorderForeground(lipgloss.Color("62"))
+			}
+			view = border.Render(view)
+		}
+
+		views[i] = view
+	}
+
+	if s.direction == Vertical {
+		return lipgloss.JoinVertical(lipgloss.Left, views...)
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, views...)
+}
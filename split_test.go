@@ -0,0 +1,81 @@
+package polymer
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSplitLayout(t *testing.T) {
+	tests := []struct {
+		name  string
+		sizes []Size
+		want  []int
+	}{
+		{"flex", []Size{Flex(1), Flex(1)}, []int{50, 50}},
+		{"weighted", []Size{Flex(1), Flex(3)}, []int{25, 75}},
+		{"remainder to last flex", []Size{Flex(1), Flex(1), Flex(1)}, []int{33, 33, 34}},
+		{"fixed and percent", []Size{Fixed(10), Percent(30), Flex(1)}, []int{10, 30, 60}},
+		{"minimum", []Size{Fixed(10), Flex(1).Min(95)}, []int{5, 95}},
+		{"minimum takes from flex", []Size{Flex(1).Min(60), Flex(1)}, []int{60, 40}},
+		{"minimums over total", []Size{Flex(1).Min(70), Flex(1).Min(70)}, []int{70, 30}},
+		{"no flex weight", []Size{Fixed(10), Percent(30), {}}, []int{10, 30, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			panes := make([]Pane, len(tt.sizes))
+			for i, size := range tt.sizes {
+				panes[i] = NewPane(newProbe("pane"), size)
+			}
+
+			split := *NewSplit("split", Horizontal, panes...)
+			split.width, split.height = 100, 10
+			if got := split.layout(); !slices.Equal(got, tt.want) {
+				t.Errorf("layout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitLayoutFollowsPosition(t *testing.T) {
+	a, b, c := newProbe("a"), newProbe("b"), newProbe("c")
+	next, _ := NewSplit("split", Horizontal,
+		NewPane(a, Fixed(10)),
+		NewPane(b, Fixed(20)),
+		NewPane(c, Flex(1)),
+	).Update(tea.WindowSizeMsg{Width: 100, Height: 10})
+
+	// A pane that returns a new Atomic keeps its size.
+	replaced := newProbe("replaced")
+	next, _ = next.Update(Envelope{Target: a.Id(), Msg: replaceMsg{replaced}})
+	split := next.(Split)
+	if got, want := split.layout(), []int{10, 20, 70}; !slices.Equal(got, want) {
+		t.Errorf("after replacing a: layout() = %v, want %v", got, want)
+	}
+
+	// A removed pane takes its size with it.
+	next, _ = split.Update(Envelope{Target: b.Id(), Msg: quitMsg{}})
+	split = next.(Split)
+	if got, want := split.layout(), []int{10, 90}; !slices.Equal(got, want) {
+		t.Errorf("after removing b: layout() = %v, want %v", got, want)
+	}
+}
+
+func TestSplitMoveDividerDoesNotAlias(t *testing.T) {
+	split := *NewSplit("split", Horizontal,
+		NewPane(newProbe("a"), Flex(1)),
+		NewPane(newProbe("b"), Flex(1)),
+	)
+	split.width, split.height = 100, 10
+
+	moved := split
+	moved.moveDivider(5)
+	if got, want := moved.layout(), []int{55, 45}; !slices.Equal(got, want) {
+		t.Errorf("moved: layout() = %v, want %v", got, want)
+	}
+	if got, want := split.layout(), []int{50, 50}; !slices.Equal(got, want) {
+		t.Errorf("original: layout() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,97 @@
+package util
+
+import (
+	"sync/atomic"
+)
+
+// Allocator allocates identifiers.
+// Implementations must be safe for concurrent use and never return 0.
+//
+// Identifiers are split into 256 ranges of 1<<24 by their top eight bits.
+// Range 0 belongs to the default allocator used by [NewId], and a
+// [Namespace] allocates from one of the ranges 1 to 255, so neither
+// overlaps another. A [Sequence] is not confined to a range.
+type Allocator interface {
+	NewId() uint32
+}
+
+// Sequence allocates increasing identifiers.
+// It is safe for concurrent use.
+type Sequence struct {
+	last atomic.Uint32
+}
+
+// NewSequence creates a [Sequence] whose first identifier is start+1.
+func NewSequence(start uint32) *Sequence {
+	s := &Sequence{}
+	s.last.Store(start)
+	return s
+}
+
+// NewId implements [Allocator].
+func (s *Sequence) NewId() uint32 {
+	return s.last.Add(1)
+}
+
+// namespaceBits is the number of low bits available to each [Namespace].
+const namespaceBits = 24
+
+// Namespace allocates identifiers within the range reserved for a namespace,
+// so identifiers from different namespaces never collide.
+// It is safe for concurrent use.
+type Namespace struct {
+	prefix uint32
+	seq    Sequence
+}
+
+// NewNamespace creates a [Namespace] for ns.
+// It panics if ns is 0, which is reserved for the default allocator.
+func NewNamespace(ns uint8) *Namespace {
+	if ns == 0 {
+		panic("namespace 0 is reserved for the default allocator")
+	}
+
+	return &Namespace{prefix: uint32(ns) << namespaceBits}
+}
+
+// NewId implements [Allocator].
+// It panics when the namespace is exhausted.
+func (n *Namespace) NewId() uint32 {
+	id := n.seq.NewId()
+	if id >= 1<<namespaceBits {
+		panic("id namespace exhausted")
+	}
+
+	return n.prefix | id
+}
+
+var allocator atomic.Value
+
+func init() {
+	// The default allocator allocates from namespace 0, starting at 2.
+	defaults := &Namespace{}
+	defaults.seq.last.Store(1)
+	allocator.Store(holder{defaults})
+}
+
+// holder keeps the stored type of allocator constant.
+type holder struct {
+	Allocator
+}
+
+// SetAllocator replaces the [Allocator] used by [NewId].
+// It returns a function that restores the previous allocator.
+func SetAllocator(a Allocator) (restore func()) {
+	if a == nil {
+		panic("allocator cannot be nil")
+	}
+
+	previous := allocator.Swap(holder{a})
+	return func() { allocator.Store(previous) }
+}
+
+// NewId generates a new unique identifier.
+// It is safe for concurrent use.
+func NewId() uint32 {
+	return allocator.Load().(holder).NewId()
+}
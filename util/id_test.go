@@ -0,0 +1,37 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestNamespaceRanges(t *testing.T) {
+	ns := NewNamespace(3)
+	if id := ns.NewId(); id>>namespaceBits != 3 || id&(1<<namespaceBits-1) == 0 {
+		t.Errorf("namespace 3 allocated %#x", id)
+	}
+
+	if id := NewId(); id>>namespaceBits != 0 {
+		t.Errorf("default allocator allocated %#x outside namespace 0", id)
+	}
+}
+
+func TestNamespaceZeroIsReserved(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewNamespace(0) did not panic")
+		}
+	}()
+	NewNamespace(0)
+}
+
+func TestSetAllocator(t *testing.T) {
+	restore := SetAllocator(NewNamespace(7))
+	if id := NewId(); id>>namespaceBits != 7 {
+		t.Errorf("NewId allocated %#x, want namespace 7", id)
+	}
+
+	restore()
+	if id := NewId(); id>>namespaceBits != 0 {
+		t.Errorf("after restore NewId allocated %#x, want namespace 0", id)
+	}
+}
@@ -4,15 +4,6 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-var currentId uint32 = 1
-
-// NewId generates a new unique identifier.
-func NewId() uint32 {
-	currentId++
-	localId := currentId
-	return localId
-}
-
 // Broadcast sends a message to the event loop.
 func Broadcast[T any](msg T) tea.Cmd {
 	return func() tea.Msg {
@@ -25,7 +16,8 @@ type ContextMsg[T any] struct {
 	Context T
 }
 
-// ContextUpdate sends a context message.
+// ContextUpdate sends a context message to every model.
+// Use polymer.Provider for values scoped to a subtree.
 func ContextUpdate[T any](ctx T) tea.Cmd {
 	return func() tea.Msg {
 		return ContextMsg[T]{Context: ctx}
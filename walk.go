@@ -0,0 +1,137 @@
+package polymer
+
+import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Container is implemented by models that hold other models.
+//
+// Children returns every live child, not only the active one, so tools can
+// operate on the whole tree. See [Walk].
+//
+// Containers are values: Update changes a copy and returns it, and earlier
+// copies stay valid. Copies share the backing arrays and maps of their
+// fields, so a container clones a slice or map before writing to it.
+type Container interface {
+	Children() []tea.Model
+}
+
+// ErrCycle is reported by [Walk] when a model appears among its own ancestors.
+var ErrCycle = errors.New("cycle in model tree")
+
+// WalkFunc is called by [Walk] for each model with its depth below the root.
+// Returning false skips the children of model.
+type WalkFunc func(model tea.Model, depth int) bool
+
+// WalkOption configures [Walk].
+type WalkOption func(*walker)
+
+// WithMaxDepth stops [Walk] from visiting models deeper than depth.
+func WithMaxDepth(depth int) WalkOption {
+	return func(w *walker) {
+		w.maxDepth = depth
+	}
+}
+
+type walker struct {
+	fn        WalkFunc
+	maxDepth  int
+	ancestors map[uint32]bool
+	errs      []error
+}
+
+// Walk visits root and its descendants depth-first, following [Container].
+//
+// A model whose id matches one of its ancestors is visited but its children
+// are not, and an error wrapping [ErrCycle] is returned.
+func Walk(root tea.Model, fn WalkFunc, options ...WalkOption) error {
+	w := &walker{
+		fn:        fn,
+		maxDepth:  -1,
+		ancestors: make(map[uint32]bool),
+	}
+
+	for _, opt := range options {
+		opt(w)
+	}
+
+	w.walk(root, 0)
+	return errors.Join(w.errs...)
+}
+
+func (w *walker) walk(model tea.Model, depth int) {
+	if model == nil || (w.maxDepth >= 0 && depth > w.maxDepth) {
+		return
+	}
+
+	if !w.fn(model, depth) {
+		return
+	}
+
+	container, ok := model.(Container)
+	if !ok {
+		return
+	}
+
+	if atom, ok := model.(HasId); ok {
+		if w.ancestors[atom.Id()] {
+			w.errs = append(w.errs, fmt.Errorf("%w: %s", ErrCycle, formatModel(model)))
+			return
+		}
+
+		w.ancestors[atom.Id()] = true
+		defer delete(w.ancestors, atom.Id())
+	}
+
+	for _, child := range container.Children() {
+		w.walk(child, depth+1)
+	}
+}
+
+// FindById returns the first [Atomic] in the tree of root with the given id.
+func FindById(root tea.Model, id uint32) (Atomic, bool) {
+	return find(root, func(atom Atomic) bool { return atom.Id() == id })
+}
+
+// FindByName returns the first [Atomic] in the tree of root with the given name.
+func FindByName(root tea.Model, name string) (Atomic, bool) {
+	return find(root, func(atom Atomic) bool { return atom.Name() == name })
+}
+
+// FindByType returns every model of type T in the tree of root.
+func FindByType[T any](root tea.Model) []T {
+	var found []T
+	Walk(root, func(model tea.Model, _ int) bool {
+		if t, ok := model.(T); ok {
+			found = append(found, t)
+		}
+		return true
+	})
+
+	return found
+}
+
+// Contains reports whether the tree of root holds an [Atomic] with the given id.
+func Contains(root tea.Model, id uint32) bool {
+	_, ok := FindById(root, id)
+	return ok
+}
+
+func find(root tea.Model, match func(Atomic) bool) (Atomic, bool) {
+	var found Atomic
+	Walk(root, func(model tea.Model, _ int) bool {
+		if found != nil {
+			return false
+		}
+		if atom, ok := model.(Atomic); ok && match(atom) {
+			found = atom
+			return false
+		}
+		return true
+	})
+
+	return found, found != nil
+}
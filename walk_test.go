@@ -0,0 +1,77 @@
+package polymer
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// loop is a container that holds a model that may be itself.
+type loop struct {
+	probe
+	child tea.Model
+}
+
+func (l *loop) Children() []tea.Model { return []tea.Model{l.child} }
+
+// visits returns the names of the models Walk visits in the tree of root,
+// with their depths.
+func visits(t *testing.T, root tea.Model, options ...WalkOption) ([]string, []int, error) {
+	t.Helper()
+	var names []string
+	var depths []int
+	err := Walk(root, func(model tea.Model, depth int) bool {
+		names = append(names, model.(HasName).Name())
+		depths = append(depths, depth)
+		return true
+	}, options...)
+	return names, depths, err
+}
+
+func TestWalk(t *testing.T) {
+	tree := NewFocusGroup("root", NewFocusGroup("inner", newProbe("a")), newProbe("b"))
+
+	names, depths, err := visits(t, tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"root", "inner", "a", "b"}; !slices.Equal(names, want) {
+		t.Errorf("visited %v, want %v", names, want)
+	}
+	if want := []int{0, 1, 2, 1}; !slices.Equal(depths, want) {
+		t.Errorf("depths %v, want %v", depths, want)
+	}
+
+	names, _, _ = visits(t, tree, WithMaxDepth(1))
+	if want := []string{"root", "inner", "b"}; !slices.Equal(names, want) {
+		t.Errorf("with max depth 1 visited %v, want %v", names, want)
+	}
+}
+
+func TestWalkReportsCycle(t *testing.T) {
+	l := &loop{probe: newProbe("loop")}
+	l.child = l
+
+	names, _, err := visits(t, l)
+	if !errors.Is(err, ErrCycle) {
+		t.Errorf("Walk() error = %v, want %v", err, ErrCycle)
+	}
+	if want := []string{"loop", "loop"}; !slices.Equal(names, want) {
+		t.Errorf("visited %v, want %v", names, want)
+	}
+}
+
+func TestFindByType(t *testing.T) {
+	a, b := newProbe("a"), newProbe("b")
+	found := FindByType[probe](NewFocusGroup("root", NewFocusGroup("inner", a), b))
+
+	ids := make([]uint32, len(found))
+	for i, p := range found {
+		ids[i] = p.Id()
+	}
+	if want := []uint32{a.Id(), b.Id()}; !slices.Equal(ids, want) {
+		t.Errorf("found ids %v, want %v", ids, want)
+	}
+}